@@ -0,0 +1,124 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/manifest"
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var workspaceApplyCmd = &cobra.Command{
+	Use:   "apply <MANIFEST>",
+	Short: "Converge a workspace to a workspace.canopy.yaml manifest",
+	Long: `Converge a workspace to the desired state in a manifest.
+
+Missing repos are added, repos not in the manifest are removed (only when they have
+no uncommitted changes or unpushed commits, unless --force) and branches are switched
+to the ones the manifest asks for. The planned changes are shown before applying.
+MANIFEST may be a file or a directory containing ` + manifest.FileName + `.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		yes, _ := cmd.Flags().GetBool("yes")
+		force, _ := cmd.Flags().GetBool("force")
+		overridePolicy, _ := cmd.Flags().GetBool("override-policy")
+
+		m, err := manifest.Load(args[0])
+		if err != nil {
+			return err
+		}
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		plan, err := app.Service.PlanApply(m)
+		if err != nil {
+			return err
+		}
+
+		if plan.Empty() {
+			fmt.Printf("Workspace %s is up to date\n", plan.WorkspaceID) //nolint:forbidigo // user-facing CLI output
+			return nil
+		}
+
+		printApplyPlan(plan)
+
+		if dryRun {
+			return nil
+		}
+
+		if !yes && isInteractiveTerminal() {
+			fmt.Print("Apply these changes? [y/N]: ") //nolint:forbidigo // user prompt
+
+			answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+			if a := strings.ToLower(strings.TrimSpace(answer)); a != "y" && a != "yes" {
+				fmt.Println("Aborted") //nolint:forbidigo // user-facing CLI output
+				return nil
+			}
+		}
+
+		if err := app.Service.Apply(plan, workspaces.ApplyOptions{Force: force, OverridePolicy: overridePolicy}); err != nil {
+			var setupErr *workspaces.SetupError
+			if errors.As(err, &setupErr) {
+				printSetupResults(setupErr.Failed)
+			}
+
+			return reportSetupError(err)
+		}
+
+		fmt.Printf("Applied manifest to workspace %s\n", plan.WorkspaceID) //nolint:forbidigo // user-facing CLI output
+
+		return nil
+	},
+}
+
+// printApplyPlan prints a plan as a diff-style preview.
+func printApplyPlan(plan *workspaces.ApplyPlan) {
+	if plan.Create {
+		fmt.Printf("+ workspace %s (branch %s)\n", plan.WorkspaceID, plan.Desired.BranchName) //nolint:forbidigo // user-facing CLI output
+	} else {
+		fmt.Printf("~ workspace %s\n", plan.WorkspaceID) //nolint:forbidigo // user-facing CLI output
+	}
+
+	for _, change := range plan.Metadata {
+		fmt.Printf("  ~ %s\n", change) //nolint:forbidigo // user-facing CLI output
+	}
+
+	for _, r := range plan.Add {
+		line := fmt.Sprintf("  + %s (%s)", r.Name, r.URL)
+		if r.BaseRef != "" {
+			line += " from " + r.BaseRef
+		}
+
+		if len(r.Setup) > 0 {
+			line += fmt.Sprintf(", %d setup steps", len(r.Setup))
+		}
+
+		fmt.Println(line) //nolint:forbidigo // user-facing CLI output
+	}
+
+	for _, r := range plan.Remove {
+		fmt.Printf("  - %s\n", r.Name) //nolint:forbidigo // user-facing CLI output
+	}
+
+	for _, change := range plan.Switch {
+		fmt.Printf("  ~ %s: %s -> %s\n", change.Repo, change.From, change.To) //nolint:forbidigo // user-facing CLI output
+	}
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceApplyCmd)
+
+	workspaceApplyCmd.Flags().Bool("dry-run", false, "Show the planned changes without applying them")
+	workspaceApplyCmd.Flags().BoolP("yes", "y", false, "Apply without confirmation")
+	workspaceApplyCmd.Flags().Bool("force", false, "Remove repos even if they have uncommitted changes or unpushed commits")
+	workspaceApplyCmd.Flags().Bool("override-policy", false, "Apply even if a branch breaks the branch policy")
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+var workspaceWaitCICmd = &cobra.Command{
+	Use:   "wait-ci <ID>",
+	Short: "Wait until CI checks pass or fail for every repository in a workspace",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		id := args[0]
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+		interval, _ := cmd.Flags().GetDuration("interval")
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		if !app.Service.ChecksConfigured() {
+			return fmt.Errorf("no forges configured; add a forges entry to the config to track CI checks")
+		}
+
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		last := domain.CheckState("")
+
+		result, err := app.Service.WaitForChecks(ctx, id, interval, func(c *domain.WorkspaceChecks) {
+			if c.State != last {
+				fmt.Printf("CI: %s\n", c.State) //nolint:forbidigo // user-facing CLI output
+				last = c.State
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		printWorkspaceChecks(result)
+
+		if result.State == domain.CheckFailure {
+			return fmt.Errorf("CI checks failed for workspace %s", id)
+		}
+
+		return nil
+	},
+}
+
+// printWorkspaceChecks prints the aggregated CI state followed by one line per repository.
+func printWorkspaceChecks(checks *domain.WorkspaceChecks) {
+	fmt.Printf("CI: %s\n", checks.State) //nolint:forbidigo // user-facing CLI output
+
+	for _, repo := range checks.Repos {
+		if repo.Error != "" {
+			fmt.Printf("- %s: ERROR: %s\n", repo.Repo, repo.Error) //nolint:forbidigo // user-facing CLI output
+			continue
+		}
+
+		fmt.Printf("- %s: %s (%d checks @ %.7s)\n", repo.Repo, repo.State, len(repo.Checks), repo.SHA) //nolint:forbidigo // user-facing CLI output
+
+		for _, c := range repo.Checks {
+			if c.State != domain.CheckSuccess {
+				fmt.Printf("    %s: %s %s\n", c.Name, c.State, c.URL) //nolint:forbidigo // user-facing CLI output
+			}
+		}
+	}
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceWaitCICmd)
+
+	workspaceWaitCICmd.Flags().Duration("timeout", 30*time.Minute, "Give up after this long")
+	workspaceWaitCICmd.Flags().Duration("interval", 15*time.Second, "Polling interval")
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var workspaceCommitCmd = &cobra.Command{
+	Use:   "commit <ID> -m <MESSAGE>",
+	Short: "Commit in every workspace repo with changes using one message",
+	Long: `Commit with the same message in every repo that has staged changes, or any changes
+to tracked files with --all. Git hooks run in each repo. With --rollback, a failure in
+one repo undoes the commits made in the others, leaving their changes staged.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		message, _ := cmd.Flags().GetString("message")
+		all, _ := cmd.Flags().GetBool("all")
+		repos, _ := cmd.Flags().GetStringSlice("repos")
+		rollback, _ := cmd.Flags().GetBool("rollback")
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		results, err := app.Service.CommitWorkspace(args[0], workspaces.CommitOptions{
+			Message:  message,
+			All:      all,
+			Selector: workspaces.RepoSelector{Repos: repos},
+			Rollback: rollback,
+		})
+		if err != nil && !errors.Is(err, workspaces.ErrCommitFailed) {
+			return err
+		}
+
+		committed := 0
+
+		for _, r := range results {
+			switch {
+			case r.Err != nil:
+				fmt.Printf("✗ %s: %v\n", r.Repo, r.Err) //nolint:forbidigo // user-facing CLI output
+			case r.RolledBack:
+				fmt.Printf("↺ %s: rolled back %s\n", r.Repo, r.SHA[:7]) //nolint:forbidigo // user-facing CLI output
+			case r.Skipped:
+				fmt.Printf("- %s: nothing to commit\n", r.Repo) //nolint:forbidigo // user-facing CLI output
+			default:
+				committed++
+				fmt.Printf("✓ %s: %s\n", r.Repo, r.SHA[:7]) //nolint:forbidigo // user-facing CLI output
+			}
+		}
+
+		if err == nil && committed == 0 {
+			return fmt.Errorf("nothing to commit in workspace %s", args[0])
+		}
+
+		return err
+	},
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceCommitCmd)
+
+	workspaceCommitCmd.Flags().StringP("message", "m", "", "Commit message")
+	workspaceCommitCmd.Flags().BoolP("all", "a", false, "Stage modified tracked files first")
+	workspaceCommitCmd.Flags().StringSlice("repos", nil, "Only commit in these repos")
+	workspaceCommitCmd.Flags().Bool("rollback", false, "Undo the other commits when one repo fails")
+	_ = workspaceCommitCmd.MarkFlagRequired("message")
+}
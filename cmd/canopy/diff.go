@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var workspaceDiffCmd = &cobra.Command{
+	Use:   "diff <ID>",
+	Short: "Show what a workspace changes across all its repos",
+	Long: `Diff every repo of a workspace against the point where its branch left the base
+(or its upstream with --upstream), covering committed and uncommitted changes.
+Untracked files are shown as added unless they are ignored.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stat, _ := cmd.Flags().GetBool("stat")
+		nameOnly, _ := cmd.Flags().GetBool("name-only")
+		upstream, _ := cmd.Flags().GetBool("upstream")
+
+		format := workspaces.DiffPatch
+
+		switch {
+		case stat && nameOnly:
+			return fmt.Errorf("--stat and --name-only are mutually exclusive")
+		case stat:
+			format = workspaces.DiffStat
+		case nameOnly:
+			format = workspaces.DiffNameOnly
+		}
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		diffs, err := app.Service.WorkspaceDiff(args[0], format, upstream)
+		if err != nil {
+			return err
+		}
+
+		for _, d := range diffs {
+			if d.Output == "" {
+				continue
+			}
+
+			fmt.Printf("=== %s (vs %s)\n%s\n\n", d.Repo, d.Base, d.Output) //nolint:forbidigo // user-facing CLI output
+		}
+
+		return nil
+	},
+}
+
+var workspaceFormatPatchCmd = &cobra.Command{
+	Use:   "format-patch <ID>",
+	Short: "Export the commits of each workspace repo as a patch series",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		outDir, _ := cmd.Flags().GetString("output-directory")
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		series, err := app.Service.FormatPatches(args[0], outDir)
+		if err != nil {
+			return err
+		}
+
+		if len(series) == 0 {
+			fmt.Println("No commits to export") //nolint:forbidigo // user-facing CLI output
+			return nil
+		}
+
+		printPatchSeries(series)
+
+		return nil
+	},
+}
+
+var workspaceAmCmd = &cobra.Command{
+	Use:   "am <ID> <DIR>",
+	Short: "Apply patch series written by format-patch onto a workspace",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		series, err := app.Service.ApplyPatches(args[0], args[1])
+		printPatchSeries(series)
+
+		return err
+	},
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceDiffCmd)
+	workspaceCmd.AddCommand(workspaceFormatPatchCmd)
+	workspaceCmd.AddCommand(workspaceAmCmd)
+
+	workspaceDiffCmd.Flags().Bool("stat", false, "Show a diffstat per repo")
+	workspaceDiffCmd.Flags().Bool("name-only", false, "Show only the names of changed files")
+	workspaceDiffCmd.Flags().Bool("patch", true, "Show the full patch (default)")
+	workspaceDiffCmd.Flags().Bool("upstream", false, "Diff against each repo's upstream branch instead of its base")
+	workspaceFormatPatchCmd.Flags().StringP("output-directory", "o", "patches", "Directory to write <repo>/*.patch into")
+}
+
+func printPatchSeries(series []workspaces.PatchSeries) {
+	for _, ps := range series {
+		fmt.Printf("%s: %d patch(es)\n", ps.Repo, len(ps.Patches)) //nolint:forbidigo // user-facing CLI output
+
+		for _, p := range ps.Patches {
+			fmt.Printf("  %s\n", p) //nolint:forbidigo // user-facing CLI output
+		}
+	}
+}
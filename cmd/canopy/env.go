@@ -0,0 +1,108 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var workspaceEnvCmd = &cobra.Command{
+	Use:   "env <ID>",
+	Short: "Print the workspace environment for eval or direnv",
+	Long: `Print the environment of a workspace: CANOPY_WORKSPACE_ID, CANOPY_WORKSPACE_PATH and
+the variables rendered from the env templates in the config.
+
+  eval "$(canopy workspace env PROJ-123)"
+
+In an .envrc, use the same line or 'dotenv <(canopy workspace env PROJ-123 --format dotenv)'.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		env, err := app.Service.WorkspaceEnv(args[0])
+		if err != nil {
+			return err
+		}
+
+		switch format {
+		case "export":
+			for _, k := range slices.Sorted(maps.Keys(env)) {
+				fmt.Printf("export %s=%s\n", k, shellQuote(env[k])) //nolint:forbidigo // user-facing CLI output
+			}
+		case "dotenv":
+			for _, k := range slices.Sorted(maps.Keys(env)) {
+				fmt.Printf("%s=%s\n", k, shellQuote(env[k])) //nolint:forbidigo // user-facing CLI output
+			}
+		case "json":
+			data, err := json.MarshalIndent(env, "", "  ")
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(data)) //nolint:forbidigo // user-facing CLI output
+		default:
+			return fmt.Errorf("unknown format %q: must be export, dotenv or json", format)
+		}
+
+		return nil
+	},
+}
+
+var workspaceExecCmd = &cobra.Command{
+	Use:   "exec <ID> -- <COMMAND> [ARGS...]",
+	Short: "Run a command in the workspace directory with the workspace environment",
+	Args:  cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		c, err := app.Service.WorkspaceCommand(cmd.Context(), args[0], args[1:])
+		if err != nil {
+			return err
+		}
+
+		c.Stdin = os.Stdin
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+
+		// The command's own exit status is passed through instead of a canopy error.
+		cmd.SilenceUsage = true
+
+		if err := c.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+				os.Exit(exitErr.ExitCode())
+			}
+
+			return err
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceEnvCmd)
+	workspaceCmd.AddCommand(workspaceExecCmd)
+
+	workspaceEnvCmd.Flags().String("format", "export", "Output format: export, dotenv or json")
+}
+
+// shellQuote quotes a value for POSIX shells.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
+}
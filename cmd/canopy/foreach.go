@@ -0,0 +1,177 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+// repoColors are cycled through to tell interleaved repo output apart.
+var repoColors = []string{"#8BE9FD", "#50FA7B", "#FFB86C", "#FF79C6", "#BD93F9", "#F1FA8C"}
+
+var workspaceForeachCmd = &cobra.Command{
+	Use:   "foreach <ID> [--repos a,b | --tag backend] [--parallel N] -- <COMMAND>",
+	Short: "Run a shell command in every repo of a workspace",
+	Long: `Run a shell command (via sh -c) in each worktree of a workspace with the workspace
+environment plus CANOPY_REPO and CANOPY_REPO_PATH. Output lines are prefixed with the repo
+name and a pass/fail summary is printed at the end. Repos can be selected by name (--repos)
+or by registry tag (--tag). A single argument is passed to the shell as written, so
+"make lint && make test" works; several arguments are quoted individually.`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repos, _ := cmd.Flags().GetStringSlice("repos")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		parallel, _ := cmd.Flags().GetInt("parallel")
+		failFast, _ := cmd.Flags().GetBool("fail-fast")
+		output, _ := cmd.Flags().GetString("output")
+
+		if output != "text" && output != "json" {
+			return fmt.Errorf("unknown output %q: must be text or json", output)
+		}
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		opts := workspaces.ForeachOptions{
+			Selector: workspaces.RepoSelector{Repos: repos, Tags: tags},
+			Command:  foreachCommand(args[1:]),
+			Parallel: parallel,
+			FailFast: failFast,
+		}
+
+		var onLine func(repo, line string)
+		if output == "text" {
+			onLine = repoLinePrinter()
+		}
+
+		// A failing command is reported by the summary, not by usage help.
+		cmd.SilenceUsage = true
+
+		results, err := app.Service.Foreach(cmd.Context(), args[0], opts, onLine)
+		if err != nil && !errors.Is(err, workspaces.ErrForeachFailed) {
+			return err
+		}
+
+		if output == "json" {
+			if encErr := printForeachJSON(results); encErr != nil {
+				return encErr
+			}
+		} else {
+			printForeachMatrix(results)
+		}
+
+		return err
+	},
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceForeachCmd)
+
+	workspaceForeachCmd.Flags().StringSlice("repos", nil, "Only run in these repos")
+	workspaceForeachCmd.Flags().StringSlice("tag", nil, "Only run in repos whose registry entry has one of these tags")
+	workspaceForeachCmd.Flags().Int("parallel", 1, "Number of repos to run at once")
+	workspaceForeachCmd.Flags().Bool("fail-fast", false, "Do not start more repos after the first failure")
+	workspaceForeachCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+}
+
+// foreachCommand turns the arguments after -- into a shell command. A lone argument is
+// treated as a shell snippet; several are quoted so each reaches the program intact.
+func foreachCommand(args []string) string {
+	if len(args) == 1 {
+		return args[0]
+	}
+
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+
+	return strings.Join(quoted, " ")
+}
+
+// repoLinePrinter returns a callback printing lines prefixed with a per-repo colored name.
+func repoLinePrinter() func(repo, line string) {
+	styles := make(map[string]lipgloss.Style)
+
+	return func(repo, line string) {
+		style, ok := styles[repo]
+		if !ok {
+			style = lipgloss.NewStyle().Foreground(lipgloss.Color(repoColors[len(styles)%len(repoColors)]))
+			styles[repo] = style
+		}
+
+		fmt.Printf("%s %s\n", style.Render("["+repo+"]"), line) //nolint:forbidigo // user-facing CLI output
+	}
+}
+
+// printForeachMatrix prints one pass/fail row per repo.
+func printForeachMatrix(results []workspaces.ForeachResult) {
+	width := len("REPO")
+	for _, r := range results {
+		width = max(width, len(r.Repo))
+	}
+
+	fmt.Printf("\n%-*s  %-7s  %4s  %s\n", width, "REPO", "RESULT", "EXIT", "TIME") //nolint:forbidigo // user-facing CLI output
+
+	for _, r := range results {
+		status := "PASS"
+
+		switch {
+		case r.Skipped:
+			status = "SKIPPED"
+		case r.Err != nil:
+			status = "FAIL"
+		}
+
+		fmt.Printf("%-*s  %-7s  %4d  %s\n", width, r.Repo, status, r.ExitCode, r.Duration.Round(time.Millisecond)) //nolint:forbidigo // user-facing CLI output
+	}
+}
+
+// foreachJSON is the --output json shape of a foreach result.
+type foreachJSON struct {
+	Repo       string `json:"repo"`
+	Status     string `json:"status"` // pass | fail | skipped
+	ExitCode   int    `json:"exit_code"`
+	DurationMS int64  `json:"duration_ms"`
+	Output     string `json:"output"`
+	Error      string `json:"error,omitempty"`
+}
+
+func printForeachJSON(results []workspaces.ForeachResult) error {
+	payload := make([]foreachJSON, 0, len(results))
+
+	for _, r := range results {
+		entry := foreachJSON{
+			Repo:       r.Repo,
+			Status:     "pass",
+			ExitCode:   r.ExitCode,
+			DurationMS: r.Duration.Milliseconds(),
+			Output:     r.Output,
+		}
+
+		switch {
+		case r.Skipped:
+			entry.Status = "skipped"
+		case r.Err != nil:
+			entry.Status = "fail"
+			entry.Error = r.Err.Error()
+		}
+
+		payload = append(payload, entry)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(payload)
+}
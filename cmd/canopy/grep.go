@@ -0,0 +1,158 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/gitx"
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var grepCmd = &cobra.Command{
+	Use:   "grep <PATTERN>",
+	Short: "Search the repos of a workspace, or every canonical repo",
+	Long: `Search with git grep across repos. By default the worktrees of a workspace are searched
+(--workspace, or the workspace containing the current directory). With --canonical, every
+repo in projects_root is searched at its default branch without a checkout.
+
+PATTERN is an extended regular expression unless --fixed-strings is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workspaceID, _ := cmd.Flags().GetString("workspace")
+		canonical, _ := cmd.Flags().GetBool("canonical")
+		repos, _ := cmd.Flags().GetStringSlice("repos")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		parallel, _ := cmd.Flags().GetInt("parallel")
+		ignoreCase, _ := cmd.Flags().GetBool("ignore-case")
+		fixed, _ := cmd.Flags().GetBool("fixed-strings")
+		output, _ := cmd.Flags().GetString("output")
+
+		if output != "text" && output != "json" {
+			return fmt.Errorf("unknown output %q: must be text or json", output)
+		}
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		opts := workspaces.GrepOptions{
+			Pattern:  args[0],
+			Selector: workspaces.RepoSelector{Repos: repos, Tags: tags},
+			Git:      gitx.GrepOptions{IgnoreCase: ignoreCase, FixedStrings: fixed},
+			Parallel: parallel,
+		}
+
+		var results []workspaces.GrepResult
+
+		if canonical {
+			results, err = app.Service.GrepCanonical(cmd.Context(), opts)
+		} else {
+			if workspaceID == "" {
+				if workspaceID, err = workspaceArgOrCwd(app, nil); err != nil {
+					return err
+				}
+			}
+
+			results, err = app.Service.GrepWorkspace(cmd.Context(), workspaceID, opts)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		if output == "json" {
+			err = printGrepJSON(results)
+		} else {
+			printGrepResults(results)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		for _, r := range results {
+			if len(r.Matches) > 0 {
+				return nil
+			}
+		}
+
+		return fmt.Errorf("no matches for %q", args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(grepCmd)
+
+	grepCmd.Flags().StringP("workspace", "w", "", "Workspace to search (default: the workspace containing the current directory)")
+	grepCmd.Flags().Bool("canonical", false, "Search every canonical repo at its default branch")
+	grepCmd.Flags().StringSlice("repos", nil, "Only search these repos")
+	grepCmd.Flags().StringSlice("tag", nil, "Only search repos whose registry entry has one of these tags")
+	grepCmd.Flags().Int("parallel", 8, "Number of repos to search at once")
+	grepCmd.Flags().BoolP("ignore-case", "i", false, "Match case-insensitively")
+	grepCmd.Flags().BoolP("fixed-strings", "F", false, "Match the pattern literally")
+	grepCmd.Flags().StringP("output", "o", "text", "Output format: text or json")
+}
+
+// printGrepResults prints matches grouped under a header per repo.
+func printGrepResults(results []workspaces.GrepResult) {
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#8BE9FD"))
+
+	for _, r := range results {
+		if r.Err != nil {
+			fmt.Fprintf(os.Stderr, "%s: %v\n", r.Repo, r.Err)
+			continue
+		}
+
+		if len(r.Matches) == 0 {
+			continue
+		}
+
+		title := r.Repo
+		if r.Ref != "" {
+			title += " @ " + r.Ref
+		}
+
+		fmt.Println(header.Render(title)) //nolint:forbidigo // user-facing CLI output
+
+		for _, m := range r.Matches {
+			fmt.Printf("  %s:%d: %s\n", m.File, m.Line, m.Text) //nolint:forbidigo // user-facing CLI output
+		}
+	}
+}
+
+// grepJSON is the --output json shape of a repo's grep result.
+type grepJSON struct {
+	Repo    string           `json:"repo"`
+	Ref     string           `json:"ref,omitempty"`
+	Matches []gitx.GrepMatch `json:"matches"`
+	Error   string           `json:"error,omitempty"`
+}
+
+func printGrepJSON(results []workspaces.GrepResult) error {
+	payload := make([]grepJSON, 0, len(results))
+
+	for _, r := range results {
+		entry := grepJSON{Repo: r.Repo, Ref: r.Ref, Matches: r.Matches}
+		if entry.Matches == nil {
+			entry.Matches = []gitx.GrepMatch{}
+		}
+
+		if r.Err != nil {
+			entry.Error = r.Err.Error()
+		}
+
+		payload = append(payload, entry)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(payload)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/gowork"
+)
+
+var workspaceGoWorkCmd = &cobra.Command{
+	Use:   "gowork <ID>",
+	Short: "Regenerate go.work from the Go modules in a workspace",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		dirs, err := app.Service.SyncGoWork(args[0], force)
+		if errors.Is(err, gowork.ErrUserManaged) {
+			return fmt.Errorf("%w; use --force to replace it", err)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(dirs) == 0 {
+			fmt.Println("No Go modules found; go.work removed") //nolint:forbidigo // user-facing CLI output
+			return nil
+		}
+
+		for _, dir := range dirs {
+			fmt.Printf("use ./%s\n", dir) //nolint:forbidigo // user-facing CLI output
+		}
+
+		return nil
+	},
+}
+
+var workspaceLinkCmd = &cobra.Command{
+	Use:   "link <ID>",
+	Short: "Regenerate files that link sibling repos (go.work, pnpm/npm workspaces, editable Python installs)",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		results, err := app.Service.LinkWorkspace(args[0])
+		for _, r := range results {
+			fmt.Printf("%-8s %s -> %s\n", r.Linker, strings.Join(r.Repos, ", "), strings.Join(r.Files, ", ")) //nolint:forbidigo // user-facing CLI output
+		}
+
+		if err != nil {
+			return err
+		}
+
+		if len(results) == 0 {
+			fmt.Println("Nothing to link") //nolint:forbidigo // user-facing CLI output
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceGoWorkCmd)
+	workspaceCmd.AddCommand(workspaceLinkCmd)
+
+	workspaceGoWorkCmd.Flags().Bool("force", false, "Replace a go.work that was not generated by canopy")
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/changelog"
+)
+
+var workspaceLogCmd = &cobra.Command{
+	Use:   "log <ID>",
+	Short: "Show the commits of a workspace across all its repos",
+	Long: `Show the commits made on the workspace branch since its base in every repo, newest
+first and tagged by repo. With --changelog, conventional commits (feat, fix, ...) are
+grouped into markdown sections for PR descriptions or release notes.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asChangelog, _ := cmd.Flags().GetBool("changelog")
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		entries, err := app.Service.WorkspaceLog(args[0])
+		if err != nil {
+			return err
+		}
+
+		if len(entries) == 0 {
+			fmt.Printf("No commits in workspace %s yet\n", args[0]) //nolint:forbidigo // user-facing CLI output
+			return nil
+		}
+
+		if asChangelog {
+			items := make([]changelog.Entry, 0, len(entries))
+			for _, e := range entries {
+				items = append(items, changelog.Entry{Repo: e.Repo, SHA: e.SHA, Subject: e.Subject, Body: e.Body})
+			}
+
+			fmt.Print(changelog.Render(items)) //nolint:forbidigo // user-facing CLI output
+
+			return nil
+		}
+
+		printLine := repoLinePrinter()
+		for _, e := range entries {
+			printLine(e.Repo, fmt.Sprintf("%s %s %s (%s)", e.SHA[:7], e.Date.Format("2006-01-02 15:04"), e.Subject, e.Author))
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceLogCmd)
+
+	workspaceLogCmd.Flags().Bool("changelog", false, "Group conventional commits into markdown")
+}
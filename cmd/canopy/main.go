@@ -5,6 +5,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -26,6 +27,18 @@ var (
 				return err
 			}
 
+			// Expired review workspaces are cleaned up opportunistically; failures must not block the command.
+			if cmd != workspacePruneCmd {
+				pruned, err := appInstance.Service.PruneExpiredWorkspaces(time.Now(), false)
+				for _, id := range pruned {
+					appInstance.Logger.Info("Removed expired review workspace", "workspace", id)
+				}
+
+				if err != nil {
+					appInstance.Logger.Warn("Failed to prune expired workspaces", "error", err)
+				}
+			}
+
 			ctx := context.WithValue(cmd.Context(), appContextKey, appInstance)
 			cmd.SetContext(ctx)
 			cmd.Root().SetContext(ctx)
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var (
+	workspacePRCmd = &cobra.Command{
+		Use:   "pr",
+		Short: "Manage pull requests for a workspace",
+	}
+
+	workspacePRCreateCmd = &cobra.Command{
+		Use:   "create <ID>",
+		Short: "Open or update a pull request for every pushed repository",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			title, _ := cmd.Flags().GetString("title")
+			body, _ := cmd.Flags().GetString("body")
+			base, _ := cmd.Flags().GetString("base")
+			draft, _ := cmd.Flags().GetBool("draft")
+
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			prs, err := app.Service.OpenPullRequests(cmd.Context(), id, workspaces.PullRequestOptions{
+				Title: title,
+				Body:  body,
+				Base:  base,
+				Draft: draft,
+			})
+			if err != nil {
+				return err
+			}
+
+			for _, pr := range prs {
+				fmt.Printf("%-20s #%-6d %s\n", pr.Repo, pr.Number, pr.URL) //nolint:forbidigo // user-facing CLI output
+			}
+			return nil
+		},
+	}
+
+	workspacePRStatusCmd = &cobra.Command{
+		Use:   "status <ID>",
+		Short: "Show pull request and review state for a workspace",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			statuses, err := app.Service.PullRequestStatuses(cmd.Context(), id)
+			if err != nil {
+				return err
+			}
+
+			if len(statuses) == 0 {
+				fmt.Printf("No pull requests recorded for workspace %s\n", id) //nolint:forbidigo // user-facing CLI output
+				return nil
+			}
+
+			for _, pr := range statuses {
+				if pr.Error != "" {
+					fmt.Printf("%-20s #%-6d ERROR: %s\n", pr.Repo, pr.Number, pr.Error) //nolint:forbidigo // user-facing CLI output
+					continue
+				}
+
+				state := pr.State
+				if pr.Draft {
+					state += " (draft)"
+				}
+
+				fmt.Printf("%-20s #%-6d %-16s %-18s %s\n", pr.Repo, pr.Number, state, pr.ReviewState, pr.URL) //nolint:forbidigo // user-facing CLI output
+			}
+			return nil
+		},
+	}
+)
+
+func init() {
+	workspaceCmd.AddCommand(workspacePRCmd)
+	workspacePRCmd.AddCommand(workspacePRCreateCmd)
+	workspacePRCmd.AddCommand(workspacePRStatusCmd)
+
+	workspacePRCreateCmd.Flags().String("title", "", "Pull request title (defaults to the workspace ID and description)")
+	workspacePRCreateCmd.Flags().String("body", "", "Pull request description shared by every repository")
+	workspacePRCreateCmd.Flags().String("base", "", "Base branch (defaults to each repository's default branch)")
+	workspacePRCreateCmd.Flags().Bool("draft", false, "Open pull requests as drafts")
+}
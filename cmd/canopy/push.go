@@ -0,0 +1,93 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var workspacePushCmd = &cobra.Command{
+	Use:   "push <ID>",
+	Short: "Push the workspace branch of every repo",
+	Long: `Push each repo's branch and set its upstream. Repos without commits beyond their base,
+branches already up to date on the remote, read-only repos and detached checkouts are
+skipped, so no empty remote branches are created. Use --force-with-lease after a rebase.
+Branches breaking the branch policy (such as protected branches) are refused unless
+--override-policy is given. Outgoing commits are scanned for secrets first and nothing
+is pushed when any are found, unless --skip-scan is given.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		remote, _ := cmd.Flags().GetString("remote")
+		force, _ := cmd.Flags().GetBool("force-with-lease")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		repos, _ := cmd.Flags().GetStringSlice("repos")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		overridePolicy, _ := cmd.Flags().GetBool("override-policy")
+		skipScan, _ := cmd.Flags().GetBool("skip-scan")
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		results, err := app.Service.PushWorkspace(args[0], workspaces.PushOptions{
+			Selector:       workspaces.RepoSelector{Repos: repos, Tags: tags},
+			Remote:         remote,
+			ForceWithLease: force,
+			DryRun:         dryRun,
+			SkipScan:       skipScan,
+			OverridePolicy: overridePolicy,
+		})
+		if err != nil && !errors.Is(err, workspaces.ErrPushFailed) {
+			return err
+		}
+
+		printPushResults(results, dryRun)
+
+		return err
+	},
+}
+
+// printPushResults prints one row per repo.
+func printPushResults(results []workspaces.PushResult, dryRun bool) {
+	repoWidth, branchWidth := len("REPO"), len("BRANCH")
+	for _, r := range results {
+		repoWidth = max(repoWidth, len(r.Repo))
+		branchWidth = max(branchWidth, len(r.Branch))
+	}
+
+	pushed := "pushed"
+	if dryRun {
+		pushed = "would push"
+	}
+
+	fmt.Printf("%-*s  %-*s  %7s  %s\n", repoWidth, "REPO", branchWidth, "BRANCH", "COMMITS", "RESULT") //nolint:forbidigo // user-facing CLI output
+
+	for _, r := range results {
+		status := pushed
+
+		switch {
+		case r.Err != nil:
+			status = "failed: " + r.Err.Error()
+		case r.Skipped != "":
+			status = "skipped (" + r.Skipped + ")"
+		}
+
+		fmt.Printf("%-*s  %-*s  %7d  %s\n", repoWidth, r.Repo, branchWidth, r.Branch, r.Commits, status) //nolint:forbidigo // user-facing CLI output
+	}
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspacePushCmd)
+
+	workspacePushCmd.Flags().String("remote", "origin", "Remote to push to")
+	workspacePushCmd.Flags().Bool("force-with-lease", false, "Overwrite the remote branch if it is where we last saw it")
+	workspacePushCmd.Flags().Bool("dry-run", false, "Show what would be pushed without pushing")
+	workspacePushCmd.Flags().StringSlice("repos", nil, "Only push these repos")
+	workspacePushCmd.Flags().StringSlice("tag", nil, "Only push repos whose registry entry has one of these tags")
+	workspacePushCmd.Flags().Bool("override-policy", false, "Push even if a branch breaks the branch policy")
+	workspacePushCmd.Flags().Bool("skip-scan", false, "Push without scanning outgoing commits for secrets")
+}
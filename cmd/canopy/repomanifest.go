@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var (
+	workspaceImportManifestCmd = &cobra.Command{
+		Use:   "import-manifest <FILE.xml>",
+		Short: "Create a workspace from a repo tool XML manifest",
+		Long: `Create a workspace from an AOSP repo tool manifest (e.g. default.xml).
+
+Remotes and projects are translated into registry entries, and each repo's
+workspace branch starts from the project's revision. Relative fetch URLs
+(e.g. "..") are resolved against --manifest-url, which defaults to the origin
+of the git checkout containing the manifest file.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, _ := cmd.Flags().GetString("id")
+			branch, _ := cmd.Flags().GetString("branch")
+			manifestURL, _ := cmd.Flags().GetString("manifest-url")
+
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			dirName, registered, err := app.Service.ImportRepoManifest(args[0], manifestURL, id, branch)
+			for _, alias := range registered {
+				fmt.Printf("Registered repository %s\n", alias) //nolint:forbidigo // user-facing CLI output
+			}
+
+			var setupErr *workspaces.SetupError
+			if err != nil && !errors.As(err, &setupErr) {
+				return err
+			}
+
+			fmt.Printf("Created workspace %s in %s/%s\n", id, app.Config.WorkspacesRoot, dirName) //nolint:forbidigo // user-facing CLI output
+
+			if setupErr != nil {
+				printSetupResults(setupErr.Failed)
+			}
+
+			return reportSetupError(err)
+		},
+	}
+
+	workspaceExportManifestCmd = &cobra.Command{
+		Use:   "export-manifest <ID>",
+		Short: "Export a workspace as a repo tool XML manifest pinned to current HEADs",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			output, _ := cmd.Flags().GetString("output")
+
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			data, err := app.Service.ExportRepoManifest(args[0])
+			if err != nil {
+				return err
+			}
+
+			if output == "" || output == "-" {
+				_, err = os.Stdout.Write(data)
+				return err
+			}
+
+			if err := os.WriteFile(output, data, 0o644); err != nil { //nolint:gosec // manifests are meant to be shared
+				return fmt.Errorf("failed to write manifest: %w", err)
+			}
+
+			fmt.Printf("Wrote %s\n", output) //nolint:forbidigo // user-facing CLI output
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	workspaceCmd.AddCommand(workspaceImportManifestCmd)
+	workspaceCmd.AddCommand(workspaceExportManifestCmd)
+
+	workspaceImportManifestCmd.Flags().String("id", "", "ID of the workspace to create")
+	workspaceImportManifestCmd.Flags().String("branch", "", "Workspace branch name (defaults to the ID)")
+	workspaceImportManifestCmd.Flags().String("manifest-url", "", "URL of the manifest repository, used to resolve relative fetch URLs")
+	_ = workspaceImportManifestCmd.MarkFlagRequired("id")
+
+	workspaceExportManifestCmd.Flags().StringP("output", "o", "", "Write the manifest to a file instead of stdout")
+}
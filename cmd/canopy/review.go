@@ -0,0 +1,106 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var (
+	workspaceReviewCmd = &cobra.Command{
+		Use:   "review <NAME>",
+		Short: "Create an ephemeral, read-only workspace to review remote branches or pull requests",
+		Long: `Create an ephemeral workspace with detached, read-only checkouts.
+
+Each --ref takes REPO=REF where REF is a branch, tag, pr/N or refs/pull/N/head.
+Read-only repositories are skipped by push, and the workspace is removed
+automatically once its TTL (review_ttl, default 72h) has elapsed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			rawRefs, _ := cmd.Flags().GetStringArray("ref")
+			ttl, _ := cmd.Flags().GetDuration("ttl")
+
+			var refs []workspaces.ReviewRef
+
+			for _, raw := range rawRefs {
+				ref, err := workspaces.ParseReviewRef(raw)
+				if err != nil {
+					return err
+				}
+
+				refs = append(refs, ref)
+			}
+
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			dirName, err := app.Service.CreateReviewWorkspace(name, refs, ttl)
+
+			var setupErr *workspaces.SetupError
+			if err != nil && !errors.As(err, &setupErr) {
+				return err
+			}
+
+			ws, wsErr := app.Service.GetWorkspace(name)
+			if wsErr != nil {
+				return wsErr
+			}
+
+			fmt.Printf("Created review workspace %s in %s/%s\n", name, app.Config.WorkspacesRoot, dirName) //nolint:forbidigo // user-facing CLI output
+			fmt.Printf("Expires: %s\n", ws.ExpiresAt.Local().Format(time.DateTime))                        //nolint:forbidigo // user-facing CLI output
+
+			if setupErr != nil {
+				printSetupResults(setupErr.Failed)
+			}
+
+			return reportSetupError(err)
+		},
+	}
+
+	workspacePruneCmd = &cobra.Command{
+		Use:   "prune",
+		Short: "Remove expired ephemeral workspaces",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			force, _ := cmd.Flags().GetBool("force")
+
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			pruned, err := app.Service.PruneExpiredWorkspaces(time.Now(), force)
+			for _, id := range pruned {
+				fmt.Printf("Removed expired workspace %s\n", id) //nolint:forbidigo // user-facing CLI output
+			}
+
+			if err != nil {
+				return err
+			}
+
+			if len(pruned) == 0 {
+				fmt.Println("No expired workspaces") //nolint:forbidigo // user-facing CLI output
+			}
+
+			return nil
+		},
+	}
+)
+
+func init() {
+	workspaceCmd.AddCommand(workspaceReviewCmd)
+	workspaceCmd.AddCommand(workspacePruneCmd)
+
+	workspaceReviewCmd.Flags().StringArray("ref", []string{}, "REPO=REF to check out (repeatable)")
+	workspaceReviewCmd.Flags().Duration("ttl", 0, "Time until the workspace is removed (defaults to review_ttl)")
+	_ = workspaceReviewCmd.MarkFlagRequired("ref")
+
+	workspacePruneCmd.Flags().Bool("force", false, "Remove expired workspaces even if they have uncommitted changes")
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/app"
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run <TASK> [ID]",
+	Short: "Run a named task in every repo of a workspace that defines it",
+	Long: `Run a task (test, build, lint, ...) declared under 'tasks' in the registry, or
+overridden per workspace in its manifest, in every repo that defines it. Repos run in
+depends_on order and are skipped when a dependency fails. Passing results are cached by
+HEAD commit and uncommitted changes of the repo and its dependencies, plus the workspace
+environment; use --no-cache to run everything again.
+
+Without an ID, the workspace containing the current directory is used.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		workspaceID, err := workspaceArgOrCwd(app, args[1:])
+		if err != nil {
+			return err
+		}
+
+		cmd.SilenceUsage = true
+
+		results, err := app.Service.RunTask(cmd.Context(), workspaceID, args[0], noCache, repoLinePrinter())
+		if err != nil && !errors.Is(err, workspaces.ErrTaskFailed) {
+			return err
+		}
+
+		printTaskReport(args[0], results)
+
+		return err
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+
+	runCmd.Flags().Bool("no-cache", false, "Run the task even where it already passed for the same inputs")
+}
+
+// workspaceArgOrCwd returns the workspace ID argument, or the workspace containing the current directory.
+func workspaceArgOrCwd(app *app.App, args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+
+	return app.Service.WorkspaceIDFromPath(cwd)
+}
+
+// printTaskReport prints one row per repo the task was considered for.
+func printTaskReport(task string, results []workspaces.TaskResult) {
+	width := len("REPO")
+	for _, r := range results {
+		width = max(width, len(r.Repo))
+	}
+
+	fmt.Printf("\n%-*s  %-7s  %-8s  %s\n", width, "REPO", "RESULT", "TIME", "COMMAND") //nolint:forbidigo // user-facing CLI output
+
+	passed := 0
+
+	for _, r := range results {
+		status := "PASS"
+
+		switch {
+		case r.Skipped:
+			status = "SKIPPED"
+		case r.Err != nil:
+			status = "FAIL"
+		case r.Cached:
+			status = "CACHED"
+			passed++
+		default:
+			passed++
+		}
+
+		fmt.Printf("%-*s  %-7s  %-8s  %s\n", width, r.Repo, status, r.Duration.Round(time.Millisecond), r.Command) //nolint:forbidigo // user-facing CLI output
+	}
+
+	fmt.Printf("%s: %d/%d passed\n", task, passed, len(results)) //nolint:forbidigo // user-facing CLI output
+}
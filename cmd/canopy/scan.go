@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/secrets"
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var workspaceScanCmd = &cobra.Command{
+	Use:   "scan <ID>",
+	Short: "Scan the commits a push would send for secrets",
+	Long: `Scan the lines added by every commit not yet on the remote branch (or beyond the base
+when the branch was never pushed) for AWS keys, private keys, high-entropy strings and the
+rules under secrets.rules in the config. This is the check 'workspace push' runs first.
+False positives can be listed in a ` + secrets.AllowlistFile + ` file at the repo root.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repos, _ := cmd.Flags().GetStringSlice("repos")
+		tags, _ := cmd.Flags().GetStringSlice("tag")
+		remote, _ := cmd.Flags().GetString("remote")
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		// Findings are reported per repo, not by usage help.
+		cmd.SilenceUsage = true
+
+		results, err := app.Service.ScanWorkspace(args[0], workspaces.ScanOptions{
+			Selector: workspaces.RepoSelector{Repos: repos, Tags: tags},
+			Remote:   remote,
+		})
+		if results == nil {
+			return err
+		}
+
+		for _, r := range results {
+			switch {
+			case r.Err != nil:
+				fmt.Printf("✗ %s: %v\n", r.Repo, r.Err) //nolint:forbidigo // user-facing CLI output
+			case len(r.Findings) == 0:
+				fmt.Printf("✓ %s: nothing found in %s\n", r.Repo, r.Range) //nolint:forbidigo // user-facing CLI output
+			default:
+				fmt.Printf("✗ %s: %d findings in %s\n", r.Repo, len(r.Findings), r.Range) //nolint:forbidigo // user-facing CLI output
+
+				for _, f := range r.Findings {
+					fmt.Printf("    %s (commit %.7s)\n", f, f.Commit) //nolint:forbidigo // user-facing CLI output
+				}
+			}
+		}
+
+		return err
+	},
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceScanCmd)
+
+	workspaceScanCmd.Flags().StringSlice("repos", nil, "Only scan these repos")
+	workspaceScanCmd.Flags().StringSlice("tag", nil, "Only scan repos whose registry entry has one of these tags")
+	workspaceScanCmd.Flags().String("remote", "origin", "Remote a push would go to")
+}
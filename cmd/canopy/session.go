@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var workspaceSessionCmd = &cobra.Command{
+	Use:   "session <ID>",
+	Short: "Create or attach a tmux/zellij session with one window per repo",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		detach, _ := cmd.Flags().GetBool("detach")
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		name, created, err := app.Service.OpenSession(args[0])
+		if err != nil {
+			return err
+		}
+
+		if detach {
+			if created {
+				fmt.Printf("Created session %s\n", name) //nolint:forbidigo // user-facing CLI output
+			} else {
+				fmt.Printf("Session %s is already running\n", name) //nolint:forbidigo // user-facing CLI output
+			}
+
+			return nil
+		}
+
+		return app.Service.AttachSession(name)
+	},
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceSessionCmd)
+
+	workspaceSessionCmd.Flags().BoolP("detach", "d", false, "Create the session without attaching to it")
+}
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var workspaceSetupCmd = &cobra.Command{
+	Use:   "setup <ID> [REPO...]",
+	Short: "Run setup commands for workspace repos",
+	Long: `Run the setup commands configured for workspace repos (registry setup, workspace
+pattern setup and manifest setup), in parallel across repos. Logs are written to
+.canopy/logs inside the workspace. Repos whose setup already succeeded are skipped
+unless --rerun is given.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		rerun, _ := cmd.Flags().GetBool("rerun")
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		results, err := app.Service.SetupWorkspace(args[0], args[1:], rerun)
+		printSetupResults(results)
+
+		return reportSetupError(err)
+	},
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceSetupCmd)
+
+	workspaceSetupCmd.Flags().Bool("rerun", false, "Run setup again for repos that already succeeded")
+}
+
+// printSetupResults prints one line per repo that ran setup.
+func printSetupResults(results []workspaces.SetupResult) {
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			continue
+		case r.Err != nil:
+			fmt.Printf("✗ %s: %v (log: %s)\n", r.Repo, r.Err, r.LogPath) //nolint:forbidigo // user-facing CLI output
+		default:
+			fmt.Printf("✓ %s: %d setup command(s)\n", r.Repo, len(r.Commands)) //nolint:forbidigo // user-facing CLI output
+		}
+	}
+}
+
+// reportSetupError turns setup failures into an error that points at the logs and rerun command.
+func reportSetupError(err error) error {
+	var setupErr *workspaces.SetupError
+	if errors.As(err, &setupErr) {
+		return fmt.Errorf("%w; see the logs above and retry with 'canopy workspace setup <ID>'", setupErr)
+	}
+
+	return err
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+var workspaceSnapshotCmd = &cobra.Command{
+	Use:   "snapshot <ID> [NAME]",
+	Short: "Save the HEADs and uncommitted changes of every workspace repo",
+	Long: `Record the branch, HEAD and uncommitted changes (including untracked files) of every
+repo under refs/canopy/snapshots/<NAME>/ without touching the worktrees. NAME defaults to
+the current time. Restore a snapshot with 'canopy workspace rollback'.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name := ""
+		if len(args) == 2 {
+			name = args[1]
+		}
+
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		snap, err := app.Service.SnapshotWorkspace(args[0], name)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Saved snapshot %s of %d repos in workspace %s\n", snap.Name, len(snap.Repos), args[0]) //nolint:forbidigo // user-facing CLI output
+
+		return nil
+	},
+}
+
+var workspaceSnapshotListCmd = &cobra.Command{
+	Use:   "list <ID>",
+	Short: "List the snapshots of a workspace",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		snapshots, err := app.Service.ListSnapshots(args[0])
+		if err != nil {
+			return err
+		}
+
+		if len(snapshots) == 0 {
+			fmt.Printf("No snapshots in workspace %s\n", args[0]) //nolint:forbidigo // user-facing CLI output
+			return nil
+		}
+
+		for _, snap := range snapshots {
+			state := "clean"
+			if snap.Dirty() {
+				state = "with uncommitted changes"
+			}
+
+			fmt.Printf("%s\t%s\t%d repos, %s\n", snap.Name, snap.CreatedAt.Local().Format("2006-01-02 15:04:05"), len(snap.Repos), state) //nolint:forbidigo // user-facing CLI output
+		}
+
+		return nil
+	},
+}
+
+var workspaceSnapshotDropCmd = &cobra.Command{
+	Use:   "drop <ID> <NAME>",
+	Short: "Delete a workspace snapshot",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		if err := app.Service.DropSnapshot(args[0], args[1]); err != nil {
+			return err
+		}
+
+		fmt.Printf("Dropped snapshot %s\n", args[1]) //nolint:forbidigo // user-facing CLI output
+
+		return nil
+	},
+}
+
+var workspaceRollbackCmd = &cobra.Command{
+	Use:   "rollback <ID> <SNAPSHOT>",
+	Short: "Restore every workspace repo to a snapshot",
+	Long: `Check out the snapshot's branch and HEAD in every repo and restore its uncommitted
+changes. The current state is saved as a "before-rollback-..." snapshot first, and if any
+repo fails to restore, the others are put back so the workspace is never left half rolled back.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		safety, err := app.Service.RollbackWorkspace(args[0], args[1])
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Rolled back workspace %s to snapshot %s (previous state saved as %s)\n", args[0], args[1], safety.Name) //nolint:forbidigo // user-facing CLI output
+
+		return nil
+	},
+}
+
+func init() {
+	workspaceCmd.AddCommand(workspaceSnapshotCmd)
+	workspaceCmd.AddCommand(workspaceRollbackCmd)
+
+	workspaceSnapshotCmd.AddCommand(workspaceSnapshotListCmd)
+	workspaceSnapshotCmd.AddCommand(workspaceSnapshotDropCmd)
+}
@@ -3,8 +3,6 @@ package main
 import (
 	"fmt"
 	"os"
-	"path/filepath"
-	"strings"
 
 	"github.com/spf13/cobra"
 )
@@ -18,25 +16,16 @@ var statusCmd = &cobra.Command{
 			return err
 		}
 
-		cfg := app.Config
-
 		cwd, err := os.Getwd()
 		if err != nil {
 			return err
 		}
 
-		// Check if we are inside a workspace
-		relPath, err := filepath.Rel(cfg.WorkspacesRoot, cwd)
-		if err != nil || strings.HasPrefix(relPath, "..") {
-			return fmt.Errorf("not inside a workspace")
-		}
-
-		// Extract workspace ID from path
-		parts := strings.Split(relPath, string(os.PathSeparator))
-		if len(parts) == 0 {
-			return fmt.Errorf("unable to determine workspace from path")
+		// Resolve the workspace containing the current directory
+		workspaceID, err := app.Service.WorkspaceIDFromPath(cwd)
+		if err != nil {
+			return err
 		}
-		workspaceID := parts[0]
 
 		status, err := app.Service.GetStatus(workspaceID)
 		if err != nil {
@@ -52,6 +41,15 @@ var statusCmd = &cobra.Command{
 			fmt.Printf("- %s: %s (Branch: %s, Unpushed: %d)\n", r.Name, statusStr, r.Branch, r.UnpushedCommits) //nolint:forbidigo // user-facing CLI output
 		}
 
+		if app.Service.ChecksConfigured() {
+			checks, err := app.Service.WorkspaceChecks(cmd.Context(), workspaceID)
+			if err != nil {
+				return err
+			}
+
+			printWorkspaceChecks(checks)
+		}
+
 		return nil
 	},
 }
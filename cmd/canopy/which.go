@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
+)
+
+var whichCmd = &cobra.Command{
+	Use:   "which <SHA|BRANCH>",
+	Short: "Find the workspaces and repos containing a commit or branch",
+	Long: `Search every active workspace, archived workspace and canonical repo for a commit
+(full or abbreviated SHA) or a branch, and report where it exists, whether it has been
+pushed and the path to cd into.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		app, err := getApp(cmd)
+		if err != nil {
+			return err
+		}
+
+		locations, err := app.Service.Which(args[0])
+		if err != nil {
+			return err
+		}
+
+		if len(locations) == 0 {
+			return fmt.Errorf("%s not found in any workspace or canonical repo", args[0])
+		}
+
+		for _, loc := range locations {
+			printLocation(loc)
+		}
+
+		for _, loc := range locations {
+			if loc.Kind == workspaces.LocationWorkspace {
+				fmt.Printf("\ncd %s\n", loc.Path) //nolint:forbidigo // user-facing CLI output
+				break
+			}
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(whichCmd)
+}
+
+func printLocation(loc workspaces.Location) {
+	pushed := "not pushed"
+	if loc.Pushed {
+		pushed = "pushed"
+	}
+
+	ref := loc.Ref
+	if loc.Match == "commit" && len(ref) > 12 {
+		ref = ref[:12]
+	}
+
+	switch loc.Kind {
+	case workspaces.LocationCanonical:
+		fmt.Printf("canonical  %s: %s %s\n    %s\n", loc.Repo, loc.Match, ref, loc.Path) //nolint:forbidigo // user-facing CLI output
+	case workspaces.LocationArchived:
+		fmt.Printf("archived   %s/%s: %s %s (%s; restore with 'canopy workspace restore %s')\n", loc.WorkspaceID, loc.Repo, loc.Match, ref, pushed, loc.WorkspaceID) //nolint:forbidigo // user-facing CLI output
+	default:
+		fmt.Printf("workspace  %s/%s: %s %s (%s)\n    %s\n", loc.WorkspaceID, loc.Repo, loc.Match, ref, pushed, loc.Path) //nolint:forbidigo // user-facing CLI output
+	}
+}
@@ -6,12 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"os/exec"
 	"strings"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/tracker"
 	"github.com/alexisbeaulieu97/canopy/internal/workspaces"
 )
 
@@ -31,6 +33,11 @@ var (
 			repos, _ := cmd.Flags().GetStringSlice("repos")
 			branch, _ := cmd.Flags().GetString("branch")
 			printPath, _ := cmd.Flags().GetBool("print-path")
+			description, _ := cmd.Flags().GetString("description")
+			labels, _ := cmd.Flags().GetStringSlice("label")
+			slug, _ := cmd.Flags().GetString("slug")
+			noTracker, _ := cmd.Flags().GetBool("no-tracker")
+			overridePolicy, _ := cmd.Flags().GetBool("override-policy")
 
 			app, err := getApp(cmd)
 			if err != nil {
@@ -58,8 +65,32 @@ var (
 				}
 			}
 
-			dirName, err := service.CreateWorkspace(id, branch, resolvedRepos)
-			if err != nil {
+			// Pre-fill description and slug from the issue tracker when the ID is a ticket key
+			if !noTracker && (description == "" || slug == "") {
+				issue, err := service.FetchIssue(cmd.Context(), id)
+				if err != nil {
+					app.Logger.Warn("Could not fetch issue details", "workspace", id, "error", err)
+				} else if issue != nil {
+					if description == "" {
+						description = issue.Title
+					}
+					if slug == "" {
+						slug = tracker.Slugify(issue.Title)
+					}
+				}
+			}
+
+			dirName, err := service.CreateWorkspaceWithOptions(domain.Workspace{
+				ID:          id,
+				BranchName:  branch,
+				Slug:        slug,
+				Description: description,
+				Labels:      labels,
+				Repos:       resolvedRepos,
+			}, workspaces.CreateOptions{OverridePolicy: overridePolicy})
+
+			var setupErr *workspaces.SetupError
+			if err != nil && !errors.As(err, &setupErr) {
 				return err
 			}
 
@@ -67,8 +98,13 @@ var (
 				fmt.Printf("%s/%s", cfg.WorkspacesRoot, dirName) //nolint:forbidigo // user-facing CLI output
 			} else {
 				fmt.Printf("Created workspace %s in %s/%s\n", id, cfg.WorkspacesRoot, dirName) //nolint:forbidigo // user-facing CLI output
+
+				if setupErr != nil {
+					printSetupResults(setupErr.Failed)
+				}
 			}
-			return nil
+
+			return reportSetupError(err)
 		},
 	}
 
@@ -124,6 +160,7 @@ var (
 
 			jsonOutput, _ := cmd.Flags().GetBool("json")
 			archivedOnly, _ := cmd.Flags().GetBool("archived")
+			labels, _ := cmd.Flags().GetStringSlice("label")
 
 			if archivedOnly {
 				archives, err := service.ListArchivedWorkspaces()
@@ -135,7 +172,9 @@ var (
 					var payload []domain.Workspace
 
 					for _, a := range archives {
-						payload = append(payload, a.Metadata)
+						if a.Metadata.HasAllLabels(labels) {
+							payload = append(payload, a.Metadata)
+						}
 					}
 
 					encoder := json.NewEncoder(os.Stdout)
@@ -144,12 +183,17 @@ var (
 				}
 
 				for _, a := range archives {
+					if !a.Metadata.HasAllLabels(labels) {
+						continue
+					}
+
 					archiveDate := "unknown"
 					if a.Metadata.ArchivedAt != nil {
 						archiveDate = a.Metadata.ArchivedAt.Format(time.RFC3339)
 					}
 
 					fmt.Printf("%s (Archived: %s)\n", a.Metadata.ID, archiveDate) //nolint:forbidigo // user-facing CLI output
+					printWorkspaceAnnotations(a.Metadata)
 					for _, r := range a.Metadata.Repos {
 						fmt.Printf("  - %s (%s)\n", r.Name, r.URL) //nolint:forbidigo // user-facing CLI output
 					}
@@ -158,11 +202,19 @@ var (
 				return nil
 			}
 
-			list, err := service.ListWorkspaces()
+			all, err := service.ListWorkspaces()
 			if err != nil {
 				return err
 			}
 
+			var list []domain.Workspace
+
+			for _, w := range all {
+				if w.HasAllLabels(labels) {
+					list = append(list, w)
+				}
+			}
+
 			if jsonOutput {
 				encoder := json.NewEncoder(os.Stdout)
 				encoder.SetIndent("", "  ")
@@ -171,6 +223,7 @@ var (
 
 			for _, w := range list {
 				fmt.Printf("%s (Branch: %s)\n", w.ID, w.BranchName) //nolint:forbidigo // user-facing CLI output
+				printWorkspaceAnnotations(w)
 				for _, r := range w.Repos {
 					fmt.Printf("  - %s (%s)\n", r.Name, r.URL) //nolint:forbidigo // user-facing CLI output
 				}
@@ -273,12 +326,20 @@ var (
 
 			service := app.Service
 
-			if err := service.AddRepoToWorkspace(workspaceID, repoName); err != nil {
+			err = service.AddRepoToWorkspace(workspaceID, repoName)
+
+			var setupErr *workspaces.SetupError
+			if err != nil && !errors.As(err, &setupErr) {
 				return err
 			}
 
 			fmt.Printf("Added repository %s to workspace %s\n", repoName, workspaceID) //nolint:forbidigo // user-facing CLI output
-			return nil
+
+			if setupErr != nil {
+				printSetupResults(setupErr.Failed)
+			}
+
+			return reportSetupError(err)
 		},
 	}
 
@@ -325,8 +386,31 @@ var (
 				return err
 			}
 
+			ws, err := service.GetWorkspace(id)
+			if err != nil {
+				return err
+			}
+
+			notes, err := service.WorkspaceNotes(id)
+			if err != nil {
+				return err
+			}
+
 			fmt.Printf("Workspace: %s\n", status.ID)      //nolint:forbidigo // user-facing CLI output
 			fmt.Printf("Branch: %s\n", status.BranchName) //nolint:forbidigo // user-facing CLI output
+			if ws.Description != "" {
+				fmt.Printf("Description: %s\n", ws.Description) //nolint:forbidigo // user-facing CLI output
+			}
+			if len(ws.Labels) > 0 {
+				fmt.Printf("Labels: %s\n", strings.Join(ws.Labels, ", ")) //nolint:forbidigo // user-facing CLI output
+			}
+
+			issue, err := service.FetchIssue(cmd.Context(), id)
+			if err != nil {
+				fmt.Printf("Issue: unavailable (%v)\n", err) //nolint:forbidigo // user-facing CLI output
+			} else if issue != nil {
+				fmt.Printf("Issue: %s [%s] %s\n", issue.Title, issue.Status, issue.URL) //nolint:forbidigo // user-facing CLI output
+			}
 
 			fmt.Println("Repositories:") //nolint:forbidigo // user-facing CLI output
 			for _, r := range status.Repos {
@@ -336,6 +420,90 @@ var (
 				}
 				fmt.Printf("  - %s: %s (Branch: %s, Unpushed: %d)\n", r.Name, statusStr, r.Branch, r.UnpushedCommits) //nolint:forbidigo // user-facing CLI output
 			}
+
+			if notes != "" {
+				fmt.Printf("Notes:\n%s", notes) //nolint:forbidigo // user-facing CLI output
+				if !strings.HasSuffix(notes, "\n") {
+					fmt.Println() //nolint:forbidigo // user-facing CLI output
+				}
+			}
+			return nil
+		},
+	}
+
+	workspaceAnnotateCmd = &cobra.Command{
+		Use:   "annotate <ID>",
+		Short: "Set the description or add notes to a workspace",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			note, _ := cmd.Flags().GetString("note")
+			edit, _ := cmd.Flags().GetBool("edit")
+
+			if !cmd.Flags().Changed("description") && note == "" && !edit {
+				return fmt.Errorf("nothing to annotate: use --description, --note or --edit")
+			}
+
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			service := app.Service
+
+			if cmd.Flags().Changed("description") {
+				description, _ := cmd.Flags().GetString("description")
+				if err := service.DescribeWorkspace(id, description); err != nil {
+					return err
+				}
+			}
+
+			if note != "" {
+				if err := service.AddWorkspaceNote(id, note); err != nil {
+					return err
+				}
+			}
+
+			if edit {
+				path, err := service.WorkspaceNotesPath(id)
+				if err != nil {
+					return err
+				}
+
+				if err := runEditor(path); err != nil {
+					return err
+				}
+			}
+
+			fmt.Printf("Updated workspace %s\n", id) //nolint:forbidigo // user-facing CLI output
+			return nil
+		},
+	}
+
+	workspaceLabelCmd = &cobra.Command{
+		Use:   "label <ID> [LABEL...]",
+		Short: "Add or remove workspace labels",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			remove, _ := cmd.Flags().GetStringSlice("remove")
+
+			app, err := getApp(cmd)
+			if err != nil {
+				return err
+			}
+
+			labels, err := app.Service.LabelWorkspace(id, args[1:], remove)
+			if err != nil {
+				return err
+			}
+
+			if len(labels) == 0 {
+				fmt.Printf("Workspace %s has no labels\n", id) //nolint:forbidigo // user-facing CLI output
+				return nil
+			}
+
+			fmt.Printf("Workspace %s labels: %s\n", id, strings.Join(labels, ", ")) //nolint:forbidigo // user-facing CLI output
 			return nil
 		},
 	}
@@ -403,6 +571,7 @@ var (
 			id := args[0]
 			branchName := args[1]
 			create, _ := cmd.Flags().GetBool("create")
+			overridePolicy, _ := cmd.Flags().GetBool("override-policy")
 
 			app, err := getApp(cmd)
 			if err != nil {
@@ -411,7 +580,7 @@ var (
 
 			service := app.Service
 
-			if err := service.SwitchBranch(id, branchName, create); err != nil {
+			if err := service.SwitchBranch(id, branchName, create, overridePolicy); err != nil {
 				return err
 			}
 
@@ -456,6 +625,40 @@ func printArchived(id string, archivedAt *time.Time) {
 	fmt.Printf("Archived workspace %s\n", id) //nolint:forbidigo // user-facing CLI output
 }
 
+func printWorkspaceAnnotations(w domain.Workspace) {
+	if w.Description != "" {
+		fmt.Printf("  %s\n", w.Description) //nolint:forbidigo // user-facing CLI output
+	}
+
+	if len(w.Labels) > 0 {
+		fmt.Printf("  Labels: %s\n", strings.Join(w.Labels, ", ")) //nolint:forbidigo // user-facing CLI output
+	}
+
+	if w.Ephemeral && w.ExpiresAt != nil {
+		fmt.Printf("  Ephemeral, expires %s\n", w.ExpiresAt.Local().Format(time.DateTime)) //nolint:forbidigo // user-facing CLI output
+	}
+}
+
+// runEditor opens path in $VISUAL or $EDITOR and waits for it to exit.
+func runEditor(path string) error {
+	editor := os.Getenv("VISUAL")
+	if editor == "" {
+		editor = os.Getenv("EDITOR")
+	}
+
+	if editor == "" {
+		return fmt.Errorf("set $EDITOR or $VISUAL to edit notes")
+	}
+
+	parts := strings.Fields(editor)
+	cmd := exec.Command(parts[0], append(parts[1:], path)...) //nolint:gosec // editor command is user-provided
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
+
 func isInteractiveTerminal() bool {
 	info, err := os.Stdin.Stat()
 	if err != nil {
@@ -477,6 +680,8 @@ func init() {
 	workspaceCmd.AddCommand(workspaceSyncCmd)
 	workspaceCmd.AddCommand(workspaceSwitchCmd)
 	workspaceCmd.AddCommand(workspaceBranchCmd)
+	workspaceCmd.AddCommand(workspaceAnnotateCmd)
+	workspaceCmd.AddCommand(workspaceLabelCmd)
 
 	// Repo subcommands
 	workspaceRepoCmd := &cobra.Command{
@@ -490,9 +695,21 @@ func init() {
 	workspaceNewCmd.Flags().StringSlice("repos", []string{}, "List of repositories to include")
 	workspaceNewCmd.Flags().String("branch", "", "Custom branch name (optional)")
 	workspaceNewCmd.Flags().Bool("print-path", false, "Print the created workspace path to stdout")
+	workspaceNewCmd.Flags().String("description", "", "Short description of the workspace")
+	workspaceNewCmd.Flags().StringSlice("label", []string{}, "Labels to attach to the workspace")
+	workspaceNewCmd.Flags().String("slug", "", "Short slug available to the workspace_naming template")
+	workspaceNewCmd.Flags().Bool("no-tracker", false, "Skip fetching issue details from the configured tracker")
+	workspaceNewCmd.Flags().Bool("override-policy", false, "Create the branch even if it breaks the branch policy")
 
 	workspaceListCmd.Flags().Bool("json", false, "Output in JSON format")
 	workspaceListCmd.Flags().Bool("archived", false, "List archived workspaces")
+	workspaceListCmd.Flags().StringSlice("label", []string{}, "Only list workspaces carrying all of these labels")
+
+	workspaceAnnotateCmd.Flags().String("description", "", "Set the workspace description (empty clears it)")
+	workspaceAnnotateCmd.Flags().String("note", "", "Append a timestamped note to the workspace notes")
+	workspaceAnnotateCmd.Flags().Bool("edit", false, "Open the workspace notes in $EDITOR")
+
+	workspaceLabelCmd.Flags().StringSlice("remove", []string{}, "Labels to remove")
 
 	workspaceCloseCmd.Flags().Bool("force", false, "Force close even if there are uncommitted changes")
 	workspaceCloseCmd.Flags().Bool("archive", false, "Archive instead of deleting")
@@ -501,4 +718,5 @@ func init() {
 	workspaceRestoreCmd.Flags().Bool("force", false, "Overwrite existing workspace if one already exists")
 
 	workspaceBranchCmd.Flags().Bool("create", false, "Create branch if it doesn't exist")
+	workspaceBranchCmd.Flags().Bool("override-policy", false, "Switch even if the branch breaks the branch policy")
 }
@@ -0,0 +1,122 @@
+// Package changelog renders conventional commits as markdown release notes.
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Entry is a commit to include in a changelog.
+type Entry struct {
+	Repo    string
+	SHA     string
+	Subject string
+	Body    string
+}
+
+// Commit is a parsed conventional commit subject.
+type Commit struct {
+	Type        string // feat, fix, ...; empty for non-conventional subjects
+	Scope       string
+	Description string
+	Breaking    bool
+}
+
+var subjectPattern = regexp.MustCompile(`^(\w+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// sections lists changelog headings in output order. Types not listed go under "Other".
+var sections = []struct {
+	types []string
+	title string
+}{
+	{[]string{"feat"}, "Features"},
+	{[]string{"fix"}, "Bug Fixes"},
+	{[]string{"perf"}, "Performance"},
+	{[]string{"refactor"}, "Refactoring"},
+	{[]string{"docs"}, "Documentation"},
+	{[]string{"test"}, "Tests"},
+	{[]string{"build", "ci"}, "Build and CI"},
+	{[]string{"chore", "style", "revert"}, "Chores"},
+}
+
+// Parse splits a conventional commit subject ("feat(api)!: add users"). Subjects that do
+// not follow the convention are returned as the description with an empty type.
+func Parse(subject, body string) Commit {
+	m := subjectPattern.FindStringSubmatch(subject)
+	if m == nil {
+		return Commit{Description: subject}
+	}
+
+	return Commit{
+		Type:        strings.ToLower(m[1]),
+		Scope:       m[2],
+		Description: m[4],
+		Breaking:    m[3] == "!" || strings.Contains(body, "BREAKING CHANGE:") || strings.Contains(body, "BREAKING-CHANGE:"),
+	}
+}
+
+// Render groups entries by conventional commit type into markdown sections. Breaking
+// changes are also listed in their own section first.
+func Render(entries []Entry) string {
+	grouped := make(map[string][]string)
+
+	var breaking []string
+
+	for _, e := range entries {
+		c := Parse(e.Subject, e.Body)
+		line := formatLine(c, e)
+
+		if c.Breaking {
+			breaking = append(breaking, line)
+		}
+
+		grouped[sectionTitle(c.Type)] = append(grouped[sectionTitle(c.Type)], line)
+	}
+
+	var b strings.Builder
+
+	writeSection(&b, "Breaking Changes", breaking)
+
+	for _, sec := range sections {
+		writeSection(&b, sec.title, grouped[sec.title])
+	}
+
+	writeSection(&b, "Other", grouped["Other"])
+
+	return strings.TrimRight(b.String(), "\n") + "\n"
+}
+
+func sectionTitle(commitType string) string {
+	for _, sec := range sections {
+		for _, t := range sec.types {
+			if t == commitType {
+				return sec.title
+			}
+		}
+	}
+
+	return "Other"
+}
+
+func formatLine(c Commit, e Entry) string {
+	sha := e.SHA
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+
+	scope := ""
+	if c.Scope != "" {
+		scope = fmt.Sprintf("**%s:** ", c.Scope)
+	}
+
+	return fmt.Sprintf("- %s%s (%s@%s)", scope, c.Description, e.Repo, sha)
+}
+
+func writeSection(b *strings.Builder, title string, lines []string) {
+	if len(lines) == 0 {
+		return
+	}
+
+	fmt.Fprintf(b, "### %s\n\n%s\n\n", title, strings.Join(lines, "\n"))
+}
@@ -0,0 +1,60 @@
+package changelog
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		subject string
+		body    string
+		want    Commit
+	}{
+		{name: "plain", subject: "Update deps", want: Commit{Description: "Update deps"}},
+		{name: "type only", subject: "fix: handle nil user", want: Commit{Type: "fix", Description: "handle nil user"}},
+		{name: "scope", subject: "feat(api): add users", want: Commit{Type: "feat", Scope: "api", Description: "add users"}},
+		{name: "bang", subject: "feat(api)!: drop v1", want: Commit{Type: "feat", Scope: "api", Description: "drop v1", Breaking: true}},
+		{name: "footer", subject: "refactor: rename field", body: "BREAKING CHANGE: clients must update", want: Commit{Type: "refactor", Description: "rename field", Breaking: true}},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.subject, tt.body); got != tt.want {
+				t.Fatalf("Parse(%q) = %+v, want %+v", tt.subject, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	got := Render([]Entry{
+		{Repo: "api", SHA: "1111111111", Subject: "feat(users)!: remove legacy endpoint"},
+		{Repo: "web", SHA: "2222222222", Subject: "fix: button alignment"},
+		{Repo: "web", SHA: "3333333333", Subject: "Bump version"},
+		{Repo: "api", SHA: "4444444444", Subject: "ci: cache modules"},
+	})
+
+	want := `### Breaking Changes
+
+- **users:** remove legacy endpoint (api@1111111)
+
+### Features
+
+- **users:** remove legacy endpoint (api@1111111)
+
+### Bug Fixes
+
+- button alignment (web@2222222)
+
+### Build and CI
+
+- cache modules (api@4444444)
+
+### Other
+
+- Bump version (web@3333333)
+`
+	if got != want {
+		t.Fatalf("Render() =\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -3,29 +3,176 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
+	"text/template"
+	"time"
 
 	"github.com/spf13/viper"
 )
 
 // Config holds the global configuration
 type Config struct {
-	ProjectsRoot       string        `mapstructure:"projects_root"`
-	WorkspacesRoot     string        `mapstructure:"workspaces_root"`
-	ArchivesRoot       string        `mapstructure:"archives_root"`
-	CloseDefault       string        `mapstructure:"workspace_close_default"`
-	WorkspaceNaming    string        `mapstructure:"workspace_naming"`
-	StaleThresholdDays int           `mapstructure:"stale_threshold_days"`
-	Defaults           Defaults      `mapstructure:"defaults"`
-	Registry           *RepoRegistry `mapstructure:"-"`
+	ProjectsRoot       string                  `mapstructure:"projects_root"`
+	WorkspacesRoot     string                  `mapstructure:"workspaces_root"`
+	ArchivesRoot       string                  `mapstructure:"archives_root"`
+	CloseDefault       string                  `mapstructure:"workspace_close_default"`
+	WorkspaceNaming    string                  `mapstructure:"workspace_naming"`
+	StaleThresholdDays int                     `mapstructure:"stale_threshold_days"`
+	ReviewTTL          time.Duration           `mapstructure:"review_ttl"`
+	GoWork             bool                    `mapstructure:"go_work"`
+	Linkers            []string                `mapstructure:"linkers"`
+	EditorFiles        []string                `mapstructure:"editor_files"`
+	Session            SessionConfig           `mapstructure:"session"`
+	Hooks              map[string][]HookConfig `mapstructure:"hooks"`
+	Env                []EnvVar                `mapstructure:"env"`
+	Policy             PolicyConfig            `mapstructure:"policy"`
+	Secrets            SecretsConfig           `mapstructure:"secrets"`
+	Defaults           Defaults                `mapstructure:"defaults"`
+	Trackers           []TrackerConfig         `mapstructure:"trackers"`
+	Forges             []ForgeConfig           `mapstructure:"forges"`
+	Registry           *RepoRegistry           `mapstructure:"-"`
+}
+
+// TrackerConfig describes an issue tracker used to enrich workspaces whose IDs are ticket keys.
+// URL templates receive .Key (the workspace ID), .Project (text before the last "-") and .Number.
+type TrackerConfig struct {
+	Name      string `mapstructure:"name"`
+	Type      string `mapstructure:"type"`       // jira | github
+	Pattern   string `mapstructure:"pattern"`    // workspace IDs handled by this tracker
+	APIURL    string `mapstructure:"api_url"`    // e.g. https://jira.example.com/rest/api/2/issue/{{.Key}}
+	BrowseURL string `mapstructure:"browse_url"` // e.g. https://jira.example.com/browse/{{.Key}}
+	Username  string `mapstructure:"username"`   // enables basic auth when set
+	Token     string `mapstructure:"token"`
+	TokenEnv  string `mapstructure:"token_env"` // environment variable holding the token
+}
+
+// ResolveToken returns the tracker token, preferring the configured environment variable.
+func (t TrackerConfig) ResolveToken() string {
+	return resolveToken(t.Token, t.TokenEnv)
+}
+
+// ForgeConfig describes a code forge hosting workspace repositories.
+type ForgeConfig struct {
+	Type     string `mapstructure:"type"`    // github | gitlab | gitea
+	Host     string `mapstructure:"host"`    // matched against the host of repository URLs
+	APIURL   string `mapstructure:"api_url"` // optional, derived from type and host when empty
+	Token    string `mapstructure:"token"`
+	TokenEnv string `mapstructure:"token_env"` // environment variable holding the token
+}
+
+// ResolveToken returns the forge token, preferring the configured environment variable.
+func (f ForgeConfig) ResolveToken() string {
+	return resolveToken(f.Token, f.TokenEnv)
+}
+
+func resolveToken(token, env string) string {
+	if env != "" {
+		if val := os.Getenv(env); val != "" {
+			return val
+		}
+	}
+
+	return token
+}
+
+// SessionConfig configures the terminal multiplexer sessions opened by `workspace session`.
+type SessionConfig struct {
+	Multiplexer string            `mapstructure:"multiplexer"` // tmux | zellij
+	Commands    map[string]string `mapstructure:"commands"`    // repo name -> startup command
+}
+
+// EnvVar is a workspace environment variable whose value is a template rendered when the
+// workspace is created. Templates receive .ID, .Slug, .Branch, .Dir, .Path, .Number and .Offset.
+type EnvVar struct {
+	Name  string `mapstructure:"name"`
+	Value string `mapstructure:"value"`
+}
+
+// EnvTemplateFuncs are the functions available to env value templates.
+var EnvTemplateFuncs = template.FuncMap{
+	"add": func(a, b int) int { return a + b },
+}
+
+// PolicyConfig restricts the branches workspaces may create, switch to and push.
+type PolicyConfig struct {
+	ProtectedBranches []string         `mapstructure:"protected_branches"` // glob patterns such as release/*
+	BranchNames       []BranchNameRule `mapstructure:"branch_names"`
+	MaxBranchLength   int              `mapstructure:"max_branch_length"` // zero means no limit
+}
+
+// BranchNameRule requires branches of matching workspaces to match a regex. The branch
+// regex is a template receiving .ID, the regex-quoted workspace ID.
+type BranchNameRule struct {
+	Workspace string `mapstructure:"workspace"` // regex matched against workspace IDs, empty matches all
+	Branch    string `mapstructure:"branch"`    // e.g. ^feature/{{.ID}}-
+}
+
+// BranchNameRuleFor returns the first branch naming rule whose workspace pattern matches.
+func (c *Config) BranchNameRuleFor(workspaceID string) (BranchNameRule, bool) {
+	for _, r := range c.Policy.BranchNames {
+		matched, err := regexp.MatchString(r.Workspace, workspaceID)
+		if err == nil && matched {
+			return r, true
+		}
+	}
+
+	return BranchNameRule{}, false
+}
+
+// BranchRegexp renders the rule's branch regex for a workspace.
+func (r BranchNameRule) BranchRegexp(workspaceID string) (*regexp.Regexp, error) {
+	tmpl, err := template.New("branch").Parse(r.Branch)
+	if err != nil {
+		return nil, err
+	}
+
+	var b strings.Builder
+	if err := tmpl.Execute(&b, map[string]string{"ID": regexp.QuoteMeta(workspaceID)}); err != nil {
+		return nil, err
+	}
+
+	return regexp.Compile(b.String())
+}
+
+// SecretsConfig extends the secret scanner run before pushing.
+type SecretsConfig struct {
+	Rules []SecretRule `mapstructure:"rules"`
+}
+
+// SecretRule flags added lines matching a regex as secrets.
+type SecretRule struct {
+	Name    string `mapstructure:"name"`
+	Pattern string `mapstructure:"pattern"`
+}
+
+// HookEvents lists the workspace lifecycle events hooks can be attached to.
+// Failing pre_* hooks veto the operation; post_* hook failures are only logged.
+var HookEvents = []string{
+	"pre_create", "post_create",
+	"pre_switch", "post_switch",
+	"pre_archive", "post_archive",
+	"pre_restore", "post_restore",
+	"pre_close", "post_close",
+}
+
+// DefaultHookTimeout bounds hooks that do not set a timeout.
+const DefaultHookTimeout = time.Minute
+
+// HookConfig is a command run on a workspace lifecycle event.
+type HookConfig struct {
+	Command string        `mapstructure:"command"` // executable path or inline shell script, run with sh -c
+	Timeout time.Duration `mapstructure:"timeout"` // defaults to DefaultHookTimeout
 }
 
 // WorkspacePattern defines a regex pattern and default repos
 type WorkspacePattern struct {
 	Pattern string   `mapstructure:"pattern"`
 	Repos   []string `mapstructure:"repos"`
+	Setup   []string `mapstructure:"setup"` // run in every repo of matching workspaces
 }
 
 // Defaults holds default configurations
@@ -52,6 +199,12 @@ func Load() (*Config, error) {
 	viper.SetDefault("workspace_close_default", "delete")
 	viper.SetDefault("workspace_naming", "{{.ID}}")
 	viper.SetDefault("stale_threshold_days", 14)
+	viper.SetDefault("review_ttl", "72h")
+	viper.SetDefault("go_work", true)
+	viper.SetDefault("linkers", []string{"node", "python"})
+	viper.SetDefault("editor_files", []string{"vscode", "jetbrains", "zed"})
+	viper.SetDefault("session.multiplexer", "tmux")
+	viper.SetDefault("policy.protected_branches", []string{"main", "master"})
 
 	viper.SetEnvPrefix("CANOPY")
 	viper.AutomaticEnv()
@@ -108,6 +261,41 @@ func (c *Config) GetReposForWorkspace(workspaceID string) []string {
 	return nil
 }
 
+// GetSetupForWorkspace returns the setup commands of the first pattern matching the workspace ID.
+func (c *Config) GetSetupForWorkspace(workspaceID string) []string {
+	for _, p := range c.Defaults.WorkspacePatterns {
+		matched, err := regexp.MatchString(p.Pattern, workspaceID)
+		if err == nil && matched {
+			return p.Setup
+		}
+	}
+
+	return nil
+}
+
+// TrackerFor returns the first tracker whose pattern matches the workspace ID.
+func (c *Config) TrackerFor(workspaceID string) (TrackerConfig, bool) {
+	for _, t := range c.Trackers {
+		matched, err := regexp.MatchString(t.Pattern, workspaceID)
+		if err == nil && matched {
+			return t, true
+		}
+	}
+
+	return TrackerConfig{}, false
+}
+
+// ForgeFor returns the forge configured for the given repository host.
+func (c *Config) ForgeFor(host string) (ForgeConfig, bool) {
+	for _, f := range c.Forges {
+		if strings.EqualFold(f.Host, host) {
+			return f, true
+		}
+	}
+
+	return ForgeConfig{}, false
+}
+
 // Validate checks if the configuration is valid
 func (c *Config) Validate() error {
 	if err := validateRoot("projects_root", c.ProjectsRoot); err != nil {
@@ -141,6 +329,149 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("stale_threshold_days must be zero or positive, got %d", c.StaleThresholdDays)
 	}
 
+	if c.ReviewTTL <= 0 {
+		return fmt.Errorf("review_ttl must be positive, got %s", c.ReviewTTL)
+	}
+
+	if _, err := template.New("workspace_naming").Parse(c.WorkspaceNaming); err != nil {
+		return fmt.Errorf("invalid workspace_naming template: %w", err)
+	}
+
+	for _, l := range c.Linkers {
+		switch l {
+		case "go", "node", "python":
+		default:
+			return fmt.Errorf("unknown linker %q: must be one of go, node, python", l)
+		}
+	}
+
+	for _, e := range c.EditorFiles {
+		switch e {
+		case "vscode", "jetbrains", "zed":
+		default:
+			return fmt.Errorf("unknown editor_files entry %q: must be one of vscode, jetbrains, zed", e)
+		}
+	}
+
+	switch strings.ToLower(c.Session.Multiplexer) {
+	case "", "tmux", "zellij":
+	default:
+		return fmt.Errorf("session.multiplexer must be 'tmux' or 'zellij', got %q", c.Session.Multiplexer)
+	}
+
+	for _, e := range c.Env {
+		if e.Name == "" || strings.ContainsAny(e.Name, "= ") {
+			return fmt.Errorf("invalid env variable name %q", e.Name)
+		}
+
+		if _, err := template.New(e.Name).Funcs(EnvTemplateFuncs).Parse(e.Value); err != nil {
+			return fmt.Errorf("invalid template for env %s: %w", e.Name, err)
+		}
+	}
+
+	if err := c.Policy.validate(); err != nil {
+		return err
+	}
+
+	for _, r := range c.Secrets.Rules {
+		if r.Name == "" {
+			return fmt.Errorf("secrets.rules: name is required for pattern %q", r.Pattern)
+		}
+
+		if _, err := regexp.Compile(r.Pattern); err != nil {
+			return fmt.Errorf("secrets.rules: invalid pattern for %s: %w", r.Name, err)
+		}
+	}
+
+	for event, hooks := range c.Hooks {
+		if !slices.Contains(HookEvents, event) {
+			return fmt.Errorf("unknown hook event %q: must be one of %s", event, strings.Join(HookEvents, ", "))
+		}
+
+		for _, h := range hooks {
+			if strings.TrimSpace(h.Command) == "" {
+				return fmt.Errorf("hook %s: command is required", event)
+			}
+
+			if h.Timeout < 0 {
+				return fmt.Errorf("hook %s: timeout must be zero or positive, got %s", event, h.Timeout)
+			}
+		}
+	}
+
+	for _, t := range c.Trackers {
+		if err := t.validate(); err != nil {
+			return err
+		}
+	}
+
+	for _, f := range c.Forges {
+		if err := f.validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (t TrackerConfig) validate() error {
+	label := t.Name
+	if label == "" {
+		label = t.Pattern
+	}
+
+	switch strings.ToLower(t.Type) {
+	case "jira", "github":
+	default:
+		return fmt.Errorf("tracker %q: type must be 'jira' or 'github', got %q", label, t.Type)
+	}
+
+	if _, err := regexp.Compile(t.Pattern); err != nil {
+		return fmt.Errorf("tracker %q: invalid pattern: %w", label, err)
+	}
+
+	if t.APIURL == "" {
+		return fmt.Errorf("tracker %q: api_url is required", label)
+	}
+
+	return nil
+}
+
+func (p PolicyConfig) validate() error {
+	for _, pattern := range p.ProtectedBranches {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("policy.protected_branches: invalid pattern %q: %w", pattern, err)
+		}
+	}
+
+	for _, r := range p.BranchNames {
+		if _, err := regexp.Compile(r.Workspace); err != nil {
+			return fmt.Errorf("policy.branch_names: invalid workspace pattern %q: %w", r.Workspace, err)
+		}
+
+		if _, err := r.BranchRegexp("PROJ-1"); err != nil {
+			return fmt.Errorf("policy.branch_names: invalid branch pattern %q: %w", r.Branch, err)
+		}
+	}
+
+	if p.MaxBranchLength < 0 {
+		return fmt.Errorf("policy.max_branch_length must be zero or positive, got %d", p.MaxBranchLength)
+	}
+
+	return nil
+}
+
+func (f ForgeConfig) validate() error {
+	switch strings.ToLower(f.Type) {
+	case "github", "gitlab", "gitea":
+	default:
+		return fmt.Errorf("forge %q: type must be 'github', 'gitlab' or 'gitea', got %q", f.Host, f.Type)
+	}
+
+	if f.Host == "" {
+		return fmt.Errorf("forge of type %q: host is required", f.Type)
+	}
+
 	return nil
 }
 
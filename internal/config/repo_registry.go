@@ -18,6 +18,14 @@ type RegistryEntry struct {
 	DefaultBranch string   `yaml:"default_branch,omitempty"`
 	Description   string   `yaml:"description,omitempty"`
 	Tags          []string `yaml:"tags,omitempty"`
+	// EditorSettings are merged into generated editor project files for workspaces using this repo.
+	EditorSettings map[string]any `yaml:"editor_settings,omitempty"`
+	// Setup commands run inside new worktrees of this repo.
+	Setup []string `yaml:"setup,omitempty"`
+	// Tasks maps task names (test, lint, ...) to shell commands for `canopy run`.
+	Tasks map[string]string `yaml:"tasks,omitempty"`
+	// DependsOn lists repos whose tasks run before this repo's in a workspace.
+	DependsOn []string `yaml:"depends_on,omitempty"`
 }
 
 // RepoRegistry stores repository aliases and metadata.
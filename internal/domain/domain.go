@@ -1,22 +1,119 @@
 // Package domain contains core domain models.
 package domain
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Repo represents a git repository
 type Repo struct {
-	Name string `yaml:"name"`
-	URL  string `yaml:"url"`
+	Name     string            `yaml:"name"`
+	URL      string            `yaml:"url"`
+	Ref      string            `yaml:"ref,omitempty"`       // detached checkout of this ref instead of a workspace branch
+	ReadOnly bool              `yaml:"read_only,omitempty"` // excluded from push
+	Branch   string            `yaml:"branch,omitempty"`    // overrides the workspace branch for this repo
+	BaseRef  string            `yaml:"base_ref,omitempty"`  // ref new branches start from
+	Setup    []string          `yaml:"setup,omitempty"`     // commands run after the worktree is created
+	Tasks    map[string]string `yaml:"tasks,omitempty"`     // per-workspace overrides of registry tasks
 }
 
 // Workspace represents a work item
 type Workspace struct {
-	ID             string     `yaml:"id"`
-	BranchName     string     `yaml:"branch_name,omitempty"`
-	Repos          []Repo     `yaml:"repos"`
-	ArchivedAt     *time.Time `yaml:"archived_at,omitempty"`
-	LastModified   time.Time  `yaml:"-"`
-	DiskUsageBytes int64      `yaml:"-"`
+	ID             string            `yaml:"id"`
+	BranchName     string            `yaml:"branch_name,omitempty"`
+	Slug           string            `yaml:"slug,omitempty"`
+	Description    string            `yaml:"description,omitempty"`
+	Labels         []string          `yaml:"labels,omitempty"`
+	Repos          []Repo            `yaml:"repos"`
+	PullRequests   []PullRequest     `yaml:"pull_requests,omitempty"`
+	Env            map[string]string `yaml:"env,omitempty"` // rendered from config when the workspace is created
+	Ephemeral      bool              `yaml:"ephemeral,omitempty"`
+	ExpiresAt      *time.Time        `yaml:"expires_at,omitempty"`
+	ArchivedAt     *time.Time        `yaml:"archived_at,omitempty"`
+	LastModified   time.Time         `yaml:"-"`
+	DiskUsageBytes int64             `yaml:"-"`
+}
+
+// Issue describes a ticket fetched from an issue tracker
+type Issue struct {
+	Key    string
+	Title  string
+	Status string
+	URL    string
+}
+
+// PullRequest records a pull request opened for a workspace repo
+type PullRequest struct {
+	Repo   string `yaml:"repo"`
+	Number int    `yaml:"number"`
+	URL    string `yaml:"url"`
+}
+
+// PullRequestStatus is the live forge state of a workspace pull request
+type PullRequestStatus struct {
+	PullRequest
+	Title       string
+	State       string // open | closed | merged
+	ReviewState string // approved | changes_requested | pending
+	Draft       bool
+	Error       string
+}
+
+// CheckState summarises the CI state of a commit or workspace
+type CheckState string
+
+// Known CI check states, ordered from least to most severe when aggregating.
+const (
+	CheckNone    CheckState = "none"
+	CheckSuccess CheckState = "success"
+	CheckPending CheckState = "pending"
+	CheckFailure CheckState = "failure"
+)
+
+// Check is a single CI status or check run
+type Check struct {
+	Name  string
+	State CheckState
+	URL   string
+}
+
+// RepoChecks holds CI results for the workspace branch HEAD of one repo
+type RepoChecks struct {
+	Repo   string
+	SHA    string
+	State  CheckState
+	Checks []Check
+	Error  string
+}
+
+// WorkspaceChecks aggregates CI results across a workspace
+type WorkspaceChecks struct {
+	ID    string
+	State CheckState
+	Repos []RepoChecks
+}
+
+// AggregateCheckStates folds states into one: any failure fails, then any pending is pending,
+// then any success succeeds. No states yields CheckNone.
+func AggregateCheckStates(states ...CheckState) CheckState {
+	result := CheckNone
+
+	for _, st := range states {
+		switch st {
+		case CheckFailure:
+			return CheckFailure
+		case CheckPending:
+			result = CheckPending
+		case CheckSuccess:
+			if result == CheckNone {
+				result = CheckSuccess
+			}
+		case CheckNone:
+		}
+	}
+
+	return result
 }
 
 // RepoStatus represents the git status of a repo
@@ -45,3 +142,30 @@ func (w Workspace) IsStale(thresholdDays int) bool {
 
 	return w.LastModified.Before(cutoff)
 }
+
+// IsExpired reports whether an ephemeral workspace has outlived its expiry time.
+func (w Workspace) IsExpired(now time.Time) bool {
+	return w.Ephemeral && w.ExpiresAt != nil && now.After(*w.ExpiresAt)
+}
+
+// HasLabel reports whether the workspace carries the label (case-insensitive).
+func (w Workspace) HasLabel(label string) bool {
+	for _, l := range w.Labels {
+		if strings.EqualFold(l, strings.TrimSpace(label)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasAllLabels reports whether the workspace carries every provided label.
+func (w Workspace) HasAllLabels(labels []string) bool {
+	for _, l := range labels {
+		if !w.HasLabel(l) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -55,3 +55,76 @@ func TestWorkspaceIsStale(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaceIsExpired(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		ws      Workspace
+		expired bool
+	}{
+		{name: "not ephemeral", ws: Workspace{ExpiresAt: &past}, expired: false},
+		{name: "no expiry", ws: Workspace{Ephemeral: true}, expired: false},
+		{name: "expired", ws: Workspace{Ephemeral: true, ExpiresAt: &past}, expired: true},
+		{name: "not yet expired", ws: Workspace{Ephemeral: true, ExpiresAt: &future}, expired: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.ws.IsExpired(now); got != tt.expired {
+				t.Fatalf("IsExpired() = %v, want %v", got, tt.expired)
+			}
+		})
+	}
+}
+
+func TestWorkspaceHasAllLabels(t *testing.T) {
+	ws := Workspace{Labels: []string{"backend", "Urgent"}}
+
+	tests := []struct {
+		name   string
+		labels []string
+		want   bool
+	}{
+		{name: "no labels requested", labels: nil, want: true},
+		{name: "single match", labels: []string{"backend"}, want: true},
+		{name: "case insensitive", labels: []string{"urgent", "BACKEND"}, want: true},
+		{name: "missing label", labels: []string{"backend", "frontend"}, want: false},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ws.HasAllLabels(tt.labels); got != tt.want {
+				t.Fatalf("HasAllLabels(%v) = %v, want %v", tt.labels, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAggregateCheckStates(t *testing.T) {
+	tests := []struct {
+		name   string
+		states []CheckState
+		want   CheckState
+	}{
+		{name: "empty", states: nil, want: CheckNone},
+		{name: "all none", states: []CheckState{CheckNone, CheckNone}, want: CheckNone},
+		{name: "success", states: []CheckState{CheckSuccess, CheckNone}, want: CheckSuccess},
+		{name: "pending wins over success", states: []CheckState{CheckSuccess, CheckPending}, want: CheckPending},
+		{name: "failure wins", states: []CheckState{CheckPending, CheckFailure, CheckSuccess}, want: CheckFailure},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if got := AggregateCheckStates(tt.states...); got != tt.want {
+				t.Fatalf("AggregateCheckStates(%v) = %s, want %s", tt.states, got, tt.want)
+			}
+		})
+	}
+}
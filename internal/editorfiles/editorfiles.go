@@ -0,0 +1,230 @@
+// Package editorfiles generates multi-root project files for editors (VS Code, JetBrains, Zed)
+// so each workspace repo is opened as its own root.
+package editorfiles
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Editors lists the supported editor kinds.
+var Editors = []string{"vscode", "jetbrains", "zed"}
+
+// ErrUnparseable is returned when an existing settings file cannot be merged safely.
+var ErrUnparseable = errors.New("existing editor file is not plain JSON; leaving it untouched")
+
+// Folder is one repo root shown in the editor.
+type Folder struct {
+	Path     string         // relative to the workspace root
+	Name     string         // display name
+	Settings map[string]any // editor settings contributed by this repo
+}
+
+// stateFile records, per editor, the setting keys canopy wrote last time so
+// keys no longer contributed by any repo can be removed without touching user keys.
+const stateFile = ".canopy-editor-settings.json"
+
+// CodeWorkspaceFile returns the VS Code workspace file name for a workspace.
+func CodeWorkspaceFile(workspaceID string) string {
+	return workspaceID + ".code-workspace"
+}
+
+// Sync writes or updates the project files of the given editors under root.
+// Only the folder list and repo-contributed settings are managed; other content is preserved.
+func Sync(root, workspaceID string, folders []Folder, editors []string) error {
+	var errs []error
+
+	managed, err := readManaged(root)
+	if err != nil {
+		return err
+	}
+
+	for _, editor := range editors {
+		var err error
+
+		switch editor {
+		case "vscode":
+			err = syncVSCode(root, workspaceID, folders, managed["vscode"])
+		case "jetbrains":
+			err = syncJetBrains(root, folders)
+		case "zed":
+			err = syncZed(root, folders, managed["zed"])
+		default:
+			err = fmt.Errorf("unknown editor %q (available: %s)", editor, strings.Join(Editors, ", "))
+		}
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", editor, err))
+
+			continue
+		}
+
+		if editor != "jetbrains" {
+			managed[editor] = settingKeys(folders)
+		}
+	}
+
+	if err := writeJSON(filepath.Join(root, stateFile), managed); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func syncVSCode(root, workspaceID string, folders []Folder, previous []string) error {
+	path := filepath.Join(root, CodeWorkspaceFile(workspaceID))
+
+	doc, err := readJSONObject(path)
+	if err != nil {
+		return err
+	}
+
+	entries := make([]map[string]string, 0, len(folders))
+	for _, f := range folders {
+		entries = append(entries, map[string]string{"path": f.Path, "name": f.Name})
+	}
+
+	doc["folders"] = entries
+	doc["settings"] = mergeSettings(doc["settings"], folders, previous)
+
+	return writeJSON(path, doc)
+}
+
+func syncZed(root string, folders []Folder, previous []string) error {
+	path := filepath.Join(root, ".zed", "settings.json")
+
+	doc, err := readJSONObject(path)
+	if err != nil {
+		return err
+	}
+
+	settings, _ := mergeSettings(doc, folders, previous).(map[string]any)
+	if _, statErr := os.Stat(path); len(settings) == 0 && os.IsNotExist(statErr) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gosec // editor directories are not sensitive
+		return err
+	}
+
+	return writeJSON(path, settings)
+}
+
+// syncJetBrains maps every repo as its own git root in .idea/vcs.xml.
+func syncJetBrains(root string, folders []Folder) error {
+	var b strings.Builder
+
+	b.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n<project version=\"4\">\n  <component name=\"VcsDirectoryMappings\">\n")
+
+	for _, f := range folders {
+		fmt.Fprintf(&b, "    <mapping directory=\"$PROJECT_DIR$/%s\" vcs=\"Git\" />\n", f.Path)
+	}
+
+	b.WriteString("  </component>\n</project>\n")
+
+	dir := filepath.Join(root, ".idea")
+	if err := os.MkdirAll(dir, 0o755); err != nil { //nolint:gosec // editor directories are not sensitive
+		return err
+	}
+
+	return os.WriteFile(filepath.Join(dir, "vcs.xml"), []byte(b.String()), 0o644) //nolint:gosec // editor files are not sensitive
+}
+
+// mergeSettings overlays repo-contributed settings onto existing ones, in folder order.
+// Keys canopy wrote previously that no repo contributes anymore are removed.
+func mergeSettings(existing any, folders []Folder, previous []string) any {
+	settings, ok := existing.(map[string]any)
+	if !ok {
+		settings = make(map[string]any)
+	}
+
+	for _, k := range previous {
+		delete(settings, k)
+	}
+
+	for _, f := range folders {
+		keys := make([]string, 0, len(f.Settings))
+		for k := range f.Settings {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			settings[k] = f.Settings[k]
+		}
+	}
+
+	return settings
+}
+
+// settingKeys returns the sorted setting keys contributed by the folders.
+func settingKeys(folders []Folder) []string {
+	seen := make(map[string]bool)
+	keys := []string{}
+
+	for _, f := range folders {
+		for k := range f.Settings {
+			if !seen[k] {
+				seen[k] = true
+
+				keys = append(keys, k)
+			}
+		}
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func readManaged(root string) (map[string][]string, error) {
+	managed := make(map[string][]string)
+
+	data, err := os.ReadFile(filepath.Join(root, stateFile)) //nolint:gosec // path is inside the workspace
+	if err != nil {
+		if os.IsNotExist(err) {
+			return managed, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &managed); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", stateFile, err)
+	}
+
+	return managed, nil
+}
+
+func readJSONObject(path string) (map[string]any, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is inside the workspace
+	if err != nil {
+		if os.IsNotExist(err) {
+			return make(map[string]any), nil
+		}
+
+		return nil, err
+	}
+
+	doc := make(map[string]any)
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, ErrUnparseable
+	}
+
+	return doc, nil
+}
+
+func writeJSON(path string, doc any) error {
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(data, '\n'), 0o644) //nolint:gosec // editor files are not sensitive
+}
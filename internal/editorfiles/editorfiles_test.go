@@ -0,0 +1,121 @@
+package editorfiles
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSyncPreservesUserContent(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, CodeWorkspaceFile("PROJ-1"))
+
+	existing := `{"folders":[{"path":"old"}],"settings":{"editor.tabSize":2},"extensions":{"recommendations":["golang.go"]}}`
+	if err := os.WriteFile(file, []byte(existing), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	folders := []Folder{
+		{Path: "api", Name: "api", Settings: map[string]any{"go.buildTags": "integration"}},
+		{Path: "web", Name: "web"},
+	}
+
+	if err := Sync(root, "PROJ-1", folders, Editors); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	var doc struct {
+		Folders    []map[string]string `json:"folders"`
+		Settings   map[string]any      `json:"settings"`
+		Extensions map[string]any      `json:"extensions"`
+	}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if len(doc.Folders) != 2 || doc.Folders[0]["path"] != "api" || doc.Folders[1]["path"] != "web" {
+		t.Fatalf("unexpected folders: %+v", doc.Folders)
+	}
+
+	if doc.Settings["editor.tabSize"] != float64(2) || doc.Settings["go.buildTags"] != "integration" || doc.Extensions == nil {
+		t.Fatalf("user content or repo settings lost: %s", data)
+	}
+
+	vcs, err := os.ReadFile(filepath.Join(root, ".idea", "vcs.xml"))
+	if err != nil || !strings.Contains(string(vcs), `$PROJECT_DIR$/web`) {
+		t.Fatalf("vcs.xml missing mappings: %s (%v)", vcs, err)
+	}
+
+	zed, err := os.ReadFile(filepath.Join(root, ".zed", "settings.json"))
+	if err != nil || !strings.Contains(string(zed), "go.buildTags") {
+		t.Fatalf("zed settings missing: %s (%v)", zed, err)
+	}
+}
+
+func TestSyncLeavesCommentedFiles(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, CodeWorkspaceFile("PROJ-2"))
+	content := "{\n  // my notes\n  \"folders\": []\n}\n"
+
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	err := Sync(root, "PROJ-2", []Folder{{Path: "api", Name: "api"}}, []string{"vscode"})
+	if !errors.Is(err, ErrUnparseable) {
+		t.Fatalf("expected ErrUnparseable, got %v", err)
+	}
+
+	if data, _ := os.ReadFile(file); string(data) != content {
+		t.Fatalf("commented file was modified:\n%s", data)
+	}
+}
+
+func TestSyncPrunesDroppedSettings(t *testing.T) {
+	root := t.TempDir()
+	file := filepath.Join(root, CodeWorkspaceFile("PROJ-3"))
+
+	if err := os.WriteFile(file, []byte(`{"settings":{"editor.tabSize":2}}`), 0o644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	folders := []Folder{{Path: "api", Name: "api", Settings: map[string]any{"go.buildTags": "integration"}}}
+	if err := Sync(root, "PROJ-3", folders, []string{"vscode", "zed"}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if err := Sync(root, "PROJ-3", []Folder{{Path: "web", Name: "web"}}, []string{"vscode", "zed"}); err != nil {
+		t.Fatalf("second Sync failed: %v", err)
+	}
+
+	var doc struct {
+		Settings map[string]any `json:"settings"`
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read file: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+
+	if _, ok := doc.Settings["go.buildTags"]; ok || doc.Settings["editor.tabSize"] != float64(2) {
+		t.Fatalf("expected repo setting pruned and user setting kept: %s", data)
+	}
+
+	zed, err := os.ReadFile(filepath.Join(root, ".zed", "settings.json"))
+	if err != nil || strings.Contains(string(zed), "go.buildTags") {
+		t.Fatalf("expected zed setting pruned: %s (%v)", zed, err)
+	}
+}
@@ -0,0 +1,145 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+// Checks reports CI results for commits on a forge.
+type Checks interface {
+	// CommitChecks returns commit statuses and check runs reported for sha.
+	CommitChecks(ctx context.Context, repo RepoRef, sha string) ([]domain.Check, error)
+}
+
+// NewChecks creates the Checks implementation for the configured forge type.
+func NewChecks(cfg config.ForgeConfig) (Checks, error) {
+	f, err := New(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	checks, ok := f.(Checks)
+	if !ok {
+		return nil, fmt.Errorf("forge type %q does not report checks", cfg.Type)
+	}
+
+	return checks, nil
+}
+
+func (g *gitHub) CommitChecks(ctx context.Context, repo RepoRef, sha string) ([]domain.Check, error) {
+	var combined struct {
+		Statuses []struct {
+			Context   string `json:"context"`
+			State     string `json:"state"`
+			TargetURL string `json:"target_url"`
+		} `json:"statuses"`
+	}
+
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/commits/%s/status", repo.Path(), sha), nil, &combined); err != nil {
+		return nil, err
+	}
+
+	var runs struct {
+		CheckRuns []struct {
+			Name       string `json:"name"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+			HTMLURL    string `json:"html_url"`
+		} `json:"check_runs"`
+	}
+
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/commits/%s/check-runs", repo.Path(), sha), nil, &runs); err != nil {
+		return nil, err
+	}
+
+	var checks []domain.Check
+
+	for _, st := range combined.Statuses {
+		checks = append(checks, domain.Check{Name: st.Context, State: statusState(st.State), URL: st.TargetURL})
+	}
+
+	for _, run := range runs.CheckRuns {
+		state := domain.CheckPending
+
+		if run.Status == "completed" {
+			switch run.Conclusion {
+			case "success", "neutral", "skipped":
+				state = domain.CheckSuccess
+			default:
+				state = domain.CheckFailure
+			}
+		}
+
+		checks = append(checks, domain.Check{Name: run.Name, State: state, URL: run.HTMLURL})
+	}
+
+	return checks, nil
+}
+
+func (g *gitLab) CommitChecks(ctx context.Context, repo RepoRef, sha string) ([]domain.Check, error) {
+	var statuses []struct {
+		Name      string `json:"name"`
+		Status    string `json:"status"`
+		TargetURL string `json:"target_url"`
+	}
+
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/repository/commits/%s/statuses", projectPath(repo), sha), nil, &statuses); err != nil {
+		return nil, err
+	}
+
+	var checks []domain.Check
+
+	for _, st := range statuses {
+		state := domain.CheckPending
+
+		switch st.Status {
+		case "success", "skipped", "manual":
+			state = domain.CheckSuccess
+		case "failed", "canceled":
+			state = domain.CheckFailure
+		}
+
+		checks = append(checks, domain.Check{Name: st.Name, State: state, URL: st.TargetURL})
+	}
+
+	return checks, nil
+}
+
+func (g *gitea) CommitChecks(ctx context.Context, repo RepoRef, sha string) ([]domain.Check, error) {
+	var combined struct {
+		Statuses []struct {
+			Context   string `json:"context"`
+			Status    string `json:"status"`
+			TargetURL string `json:"target_url"`
+		} `json:"statuses"`
+	}
+
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/commits/%s/status", repo.Path(), sha), nil, &combined); err != nil {
+		return nil, err
+	}
+
+	var checks []domain.Check
+
+	for _, st := range combined.Statuses {
+		checks = append(checks, domain.Check{Name: st.Context, State: statusState(st.Status), URL: st.TargetURL})
+	}
+
+	return checks, nil
+}
+
+// statusState maps commit status strings shared by GitHub and Gitea.
+func statusState(raw string) domain.CheckState {
+	switch strings.ToLower(raw) {
+	case "success", "warning":
+		return domain.CheckSuccess
+	case "failure", "error":
+		return domain.CheckFailure
+	default:
+		return domain.CheckPending
+	}
+}
@@ -0,0 +1,226 @@
+// Package forge talks to code forges (GitHub, GitLab, Gitea) about workspace branches.
+package forge
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+)
+
+// Review states reported by PullRequest.ReviewState.
+const (
+	ReviewApproved         = "approved"
+	ReviewChangesRequested = "changes_requested"
+	ReviewPending          = "pending"
+)
+
+// Pull request states reported by PullRequest.State.
+const (
+	StateOpen   = "open"
+	StateClosed = "closed"
+	StateMerged = "merged"
+)
+
+// Forge manages pull requests on a hosting service.
+type Forge interface {
+	// FindPullRequest returns the open pull request for head, or nil when none exists.
+	FindPullRequest(ctx context.Context, repo RepoRef, head string) (*PullRequest, error)
+	CreatePullRequest(ctx context.Context, repo RepoRef, req PullRequestRequest) (*PullRequest, error)
+	UpdatePullRequest(ctx context.Context, repo RepoRef, number int, req PullRequestRequest) (*PullRequest, error)
+	// GetPullRequest returns the pull request including its review state.
+	GetPullRequest(ctx context.Context, repo RepoRef, number int) (*PullRequest, error)
+}
+
+// RepoRef identifies a repository on a forge.
+type RepoRef struct {
+	Host  string
+	Owner string // may contain subgroups on GitLab
+	Name  string
+}
+
+// Path returns the owner/name path of the repository.
+func (r RepoRef) Path() string {
+	return r.Owner + "/" + r.Name
+}
+
+// PullRequestRequest holds the fields used to open or update a pull request.
+type PullRequestRequest struct {
+	Title string
+	Body  string
+	Head  string
+	Base  string
+	Draft bool
+}
+
+// PullRequest is a pull (or merge) request as reported by a forge.
+type PullRequest struct {
+	Number      int
+	URL         string
+	Title       string
+	Body        string
+	State       string
+	ReviewState string
+	Draft       bool
+}
+
+// draftTitle returns the title of req, marked with prefix for forges that track drafts by
+// title. Updates must mark it too, or re-sending the title would undo the draft.
+func draftTitle(req PullRequestRequest, prefix string) string {
+	if req.Draft && !strings.HasPrefix(req.Title, prefix) {
+		return prefix + " " + req.Title
+	}
+
+	return req.Title
+}
+
+// New creates the Forge implementation for the configured type.
+func New(cfg config.ForgeConfig) (Forge, error) {
+	c := &client{
+		apiURL:     strings.TrimSuffix(cfg.APIURL, "/"),
+		token:      cfg.ResolveToken(),
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}
+
+	switch strings.ToLower(cfg.Type) {
+	case "github":
+		if c.apiURL == "" {
+			c.apiURL = "https://api.github.com"
+			if !strings.EqualFold(cfg.Host, "github.com") {
+				c.apiURL = "https://" + cfg.Host + "/api/v3"
+			}
+		}
+
+		c.authHeader, c.authPrefix = "Authorization", "Bearer "
+
+		return &gitHub{client: c}, nil
+	case "gitlab":
+		if c.apiURL == "" {
+			c.apiURL = "https://" + cfg.Host + "/api/v4"
+		}
+
+		c.authHeader, c.authPrefix = "PRIVATE-TOKEN", ""
+
+		return &gitLab{client: c}, nil
+	case "gitea":
+		if c.apiURL == "" {
+			c.apiURL = "https://" + cfg.Host + "/api/v1"
+		}
+
+		c.authHeader, c.authPrefix = "Authorization", "token "
+
+		return &gitea{client: c}, nil
+	default:
+		return nil, fmt.Errorf("unsupported forge type %q", cfg.Type)
+	}
+}
+
+// ParseRepoURL extracts the host and repository path from a git remote URL.
+func ParseRepoURL(raw string) (RepoRef, error) {
+	raw = strings.TrimSpace(raw)
+
+	var host, path string
+
+	switch {
+	case strings.HasPrefix(raw, "file://") || strings.HasPrefix(raw, "/"):
+		return RepoRef{}, fmt.Errorf("local repository %s is not hosted on a forge", raw)
+	case strings.Contains(raw, "://"):
+		u, err := url.Parse(raw)
+		if err != nil {
+			return RepoRef{}, fmt.Errorf("invalid repository URL %s: %w", raw, err)
+		}
+
+		host, path = u.Hostname(), u.Path
+	case strings.Contains(raw, ":"):
+		// scp-like syntax: git@host:owner/repo.git
+		parts := strings.SplitN(raw, ":", 2)
+		host = parts[0]
+
+		if idx := strings.LastIndex(host, "@"); idx >= 0 {
+			host = host[idx+1:]
+		}
+
+		path = parts[1]
+	default:
+		return RepoRef{}, fmt.Errorf("unrecognized repository URL %s", raw)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+
+	idx := strings.LastIndex(path, "/")
+	if host == "" || idx <= 0 {
+		return RepoRef{}, fmt.Errorf("repository URL %s must include an owner and name", raw)
+	}
+
+	return RepoRef{Host: host, Owner: path[:idx], Name: path[idx+1:]}, nil
+}
+
+// client is the shared JSON-over-HTTP transport used by forge implementations.
+type client struct {
+	apiURL     string
+	token      string
+	authHeader string
+	authPrefix string
+	httpClient *http.Client
+}
+
+func (c *client) do(ctx context.Context, method, path string, in, out any) error {
+	var body io.Reader
+
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.apiURL+path, body)
+	if err != nil {
+		return fmt.Errorf("failed to build forge request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	if c.token != "" {
+		req.Header.Set(c.authHeader, c.authPrefix+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("forge request failed: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 4<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read forge response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%s %s returned %s: %s", method, path, resp.Status, strings.TrimSpace(string(data)))
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(data, out); err != nil {
+		return fmt.Errorf("failed to decode forge response: %w", err)
+	}
+
+	return nil
+}
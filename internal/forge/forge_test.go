@@ -0,0 +1,209 @@
+package forge
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestParseRepoURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		want    RepoRef
+		wantErr bool
+	}{
+		{url: "https://github.com/acme/api.git", want: RepoRef{Host: "github.com", Owner: "acme", Name: "api"}},
+		{url: "git@github.com:acme/api.git", want: RepoRef{Host: "github.com", Owner: "acme", Name: "api"}},
+		{url: "ssh://git@gitlab.example.com:2222/group/sub/web.git", want: RepoRef{Host: "gitlab.example.com", Owner: "group/sub", Name: "web"}},
+		{url: "https://gitea.example.com/team/tool/", want: RepoRef{Host: "gitea.example.com", Owner: "team", Name: "tool"}},
+		{url: "file:///tmp/repo.git", wantErr: true},
+		{url: "https://github.com/api", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRepoURL(tt.url)
+		if tt.wantErr {
+			if err == nil {
+				t.Fatalf("ParseRepoURL(%q) expected error", tt.url)
+			}
+
+			continue
+		}
+
+		if err != nil {
+			t.Fatalf("ParseRepoURL(%q) failed: %v", tt.url, err)
+		}
+
+		if got != tt.want {
+			t.Fatalf("ParseRepoURL(%q) = %+v, want %+v", tt.url, got, tt.want)
+		}
+	}
+}
+
+func TestGitHubPullRequestLifecycle(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/api/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("head") != "acme:PROJ-1" {
+			t.Errorf("unexpected head filter %q", r.URL.Query().Get("head"))
+		}
+
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("POST /repos/acme/api/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		if payload["head"] != "PROJ-1" || payload["base"] != "main" {
+			t.Errorf("unexpected payload %v", payload)
+		}
+
+		_, _ = w.Write([]byte(`{"number":7,"html_url":"https://github.com/acme/api/pull/7","title":"PROJ-1","state":"open"}`))
+	})
+	mux.HandleFunc("GET /repos/acme/api/pulls/7", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"number":7,"html_url":"https://github.com/acme/api/pull/7","state":"closed","merged":true}`))
+	})
+	mux.HandleFunc("GET /repos/acme/api/pulls/7/reviews", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[{"user":{"login":"a"},"state":"CHANGES_REQUESTED"},{"user":{"login":"a"},"state":"APPROVED"},{"user":{"login":"b"},"state":"COMMENTED"}]`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	f, err := New(config.ForgeConfig{Type: "github", Host: "github.com", APIURL: server.URL, Token: "tok"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	repo := RepoRef{Host: "github.com", Owner: "acme", Name: "api"}
+	ctx := context.Background()
+
+	existing, err := f.FindPullRequest(ctx, repo, "PROJ-1")
+	if err != nil || existing != nil {
+		t.Fatalf("expected no existing pull request, got %+v (%v)", existing, err)
+	}
+
+	created, err := f.CreatePullRequest(ctx, repo, PullRequestRequest{Title: "PROJ-1", Head: "PROJ-1", Base: "main"})
+	if err != nil {
+		t.Fatalf("CreatePullRequest failed: %v", err)
+	}
+
+	if created.Number != 7 || created.URL != "https://github.com/acme/api/pull/7" {
+		t.Fatalf("unexpected pull request: %+v", created)
+	}
+
+	status, err := f.GetPullRequest(ctx, repo, 7)
+	if err != nil {
+		t.Fatalf("GetPullRequest failed: %v", err)
+	}
+
+	if status.State != StateMerged || status.ReviewState != ReviewApproved {
+		t.Fatalf("unexpected status: %+v", status)
+	}
+}
+
+func TestGitLabGetMergeRequest(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v4/projects/{project}/merge_requests/3", func(w http.ResponseWriter, r *http.Request) {
+		if r.PathValue("project") != "group/web" || r.Header.Get("PRIVATE-TOKEN") != "tok" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"iid":3,"web_url":"https://gitlab.example.com/group/web/-/merge_requests/3","state":"opened","draft":true}`))
+	})
+	mux.HandleFunc("GET /api/v4/projects/{project}/merge_requests/3/approvals", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"approved":false}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	f, err := New(config.ForgeConfig{Type: "gitlab", Host: "gitlab.example.com", APIURL: server.URL + "/api/v4", Token: "tok"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	mr, err := f.GetPullRequest(context.Background(), RepoRef{Owner: "group", Name: "web"}, 3)
+	if err != nil {
+		t.Fatalf("GetPullRequest failed: %v", err)
+	}
+
+	if mr.State != StateOpen || !mr.Draft || mr.ReviewState != ReviewPending {
+		t.Fatalf("unexpected merge request: %+v", mr)
+	}
+}
+
+func TestGiteaFindPullRequestFiltersHead(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token tok" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`[{"number":1,"head":{"ref":"other"}},{"number":2,"html_url":"https://gitea.example.com/team/tool/pulls/2","head":{"ref":"PROJ-1"},"state":"open"}]`))
+	}))
+	defer server.Close()
+
+	f, err := New(config.ForgeConfig{Type: "gitea", Host: "gitea.example.com", APIURL: server.URL, Token: "tok"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	pr, err := f.FindPullRequest(context.Background(), RepoRef{Owner: "team", Name: "tool"}, "PROJ-1")
+	if err != nil {
+		t.Fatalf("FindPullRequest failed: %v", err)
+	}
+
+	if pr == nil || pr.Number != 2 {
+		t.Fatalf("expected pull request 2, got %+v", pr)
+	}
+}
+
+func TestGitHubCommitChecks(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/api/commits/abc123/status", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"statuses":[{"context":"ci/jenkins","state":"success"}]}`))
+	})
+	mux.HandleFunc("GET /repos/acme/api/commits/abc123/check-runs", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"check_runs":[{"name":"lint","status":"completed","conclusion":"failure"},{"name":"test","status":"in_progress"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	checks, err := NewChecks(config.ForgeConfig{Type: "github", Host: "github.com", APIURL: server.URL})
+	if err != nil {
+		t.Fatalf("NewChecks failed: %v", err)
+	}
+
+	results, err := checks.CommitChecks(context.Background(), RepoRef{Owner: "acme", Name: "api"}, "abc123")
+	if err != nil {
+		t.Fatalf("CommitChecks failed: %v", err)
+	}
+
+	want := map[string]domain.CheckState{
+		"ci/jenkins": domain.CheckSuccess,
+		"lint":       domain.CheckFailure,
+		"test":       domain.CheckPending,
+	}
+
+	if len(results) != len(want) {
+		t.Fatalf("expected %d checks, got %+v", len(want), results)
+	}
+
+	for _, c := range results {
+		if want[c.Name] != c.State {
+			t.Fatalf("check %s: got %s, want %s", c.Name, c.State, want[c.Name])
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+type gitea struct {
+	client *client
+}
+
+type giteaPull struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	Merged  bool   `json:"merged"`
+	Draft   bool   `json:"draft"`
+	Head    struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+}
+
+func (p giteaPull) toPullRequest() *PullRequest {
+	state := p.State
+	if p.Merged {
+		state = StateMerged
+	}
+
+	return &PullRequest{
+		Number: p.Number,
+		URL:    p.HTMLURL,
+		Title:  p.Title,
+		Body:   p.Body,
+		State:  state,
+		Draft:  p.Draft,
+	}
+}
+
+func (g *gitea) FindPullRequest(ctx context.Context, repo RepoRef, head string) (*PullRequest, error) {
+	// Gitea has no head filter on the list endpoint, so filter client-side.
+	var pulls []giteaPull
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/pulls?state=open&limit=50", repo.Path()), nil, &pulls); err != nil {
+		return nil, err
+	}
+
+	for _, p := range pulls {
+		if p.Head.Ref == head {
+			return p.toPullRequest(), nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (g *gitea) CreatePullRequest(ctx context.Context, repo RepoRef, req PullRequestRequest) (*PullRequest, error) {
+	payload := map[string]any{
+		"title": draftTitle(req, "WIP:"),
+		"body":  req.Body,
+		"head":  req.Head,
+		"base":  req.Base,
+	}
+
+	var pull giteaPull
+	if err := g.client.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/pulls", repo.Path()), payload, &pull); err != nil {
+		return nil, err
+	}
+
+	return pull.toPullRequest(), nil
+}
+
+func (g *gitea) UpdatePullRequest(ctx context.Context, repo RepoRef, number int, req PullRequestRequest) (*PullRequest, error) {
+	payload := map[string]any{"title": draftTitle(req, "WIP:"), "body": req.Body}
+
+	var pull giteaPull
+	if err := g.client.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/pulls/%d", repo.Path(), number), payload, &pull); err != nil {
+		return nil, err
+	}
+
+	return pull.toPullRequest(), nil
+}
+
+func (g *gitea) GetPullRequest(ctx context.Context, repo RepoRef, number int) (*PullRequest, error) {
+	var pull giteaPull
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/pulls/%d", repo.Path(), number), nil, &pull); err != nil {
+		return nil, err
+	}
+
+	var reviews []struct {
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		State string `json:"state"`
+	}
+
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/pulls/%d/reviews", repo.Path(), number), nil, &reviews); err != nil {
+		return nil, err
+	}
+
+	latest := make(map[string]string)
+
+	for _, r := range reviews {
+		if r.State == "APPROVED" || r.State == "REQUEST_CHANGES" {
+			latest[r.User.Login] = r.State
+		}
+	}
+
+	result := pull.toPullRequest()
+	result.ReviewState = summarizeReviews(latest, "APPROVED", "REQUEST_CHANGES")
+
+	return result, nil
+}
@@ -0,0 +1,129 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type gitHub struct {
+	client *client
+}
+
+type gitHubPull struct {
+	Number  int    `json:"number"`
+	HTMLURL string `json:"html_url"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	State   string `json:"state"`
+	Draft   bool   `json:"draft"`
+	Merged  bool   `json:"merged"`
+}
+
+func (p gitHubPull) toPullRequest() *PullRequest {
+	state := p.State
+	if p.Merged {
+		state = StateMerged
+	}
+
+	return &PullRequest{
+		Number: p.Number,
+		URL:    p.HTMLURL,
+		Title:  p.Title,
+		Body:   p.Body,
+		State:  state,
+		Draft:  p.Draft,
+	}
+}
+
+func (g *gitHub) FindPullRequest(ctx context.Context, repo RepoRef, head string) (*PullRequest, error) {
+	query := url.Values{"head": {repo.Owner + ":" + head}, "state": {"open"}}
+
+	var pulls []gitHubPull
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/pulls?%s", repo.Path(), query.Encode()), nil, &pulls); err != nil {
+		return nil, err
+	}
+
+	if len(pulls) == 0 {
+		return nil, nil
+	}
+
+	return pulls[0].toPullRequest(), nil
+}
+
+func (g *gitHub) CreatePullRequest(ctx context.Context, repo RepoRef, req PullRequestRequest) (*PullRequest, error) {
+	payload := map[string]any{
+		"title": req.Title,
+		"body":  req.Body,
+		"head":  req.Head,
+		"base":  req.Base,
+		"draft": req.Draft,
+	}
+
+	var pull gitHubPull
+	if err := g.client.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/pulls", repo.Path()), payload, &pull); err != nil {
+		return nil, err
+	}
+
+	return pull.toPullRequest(), nil
+}
+
+func (g *gitHub) UpdatePullRequest(ctx context.Context, repo RepoRef, number int, req PullRequestRequest) (*PullRequest, error) {
+	payload := map[string]any{"title": req.Title, "body": req.Body}
+
+	var pull gitHubPull
+	if err := g.client.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/pulls/%d", repo.Path(), number), payload, &pull); err != nil {
+		return nil, err
+	}
+
+	return pull.toPullRequest(), nil
+}
+
+func (g *gitHub) GetPullRequest(ctx context.Context, repo RepoRef, number int) (*PullRequest, error) {
+	var pull gitHubPull
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/pulls/%d", repo.Path(), number), nil, &pull); err != nil {
+		return nil, err
+	}
+
+	var reviews []struct {
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		State string `json:"state"`
+	}
+
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("/repos/%s/pulls/%d/reviews", repo.Path(), number), nil, &reviews); err != nil {
+		return nil, err
+	}
+
+	// Reviews are returned chronologically; the latest decisive review per user wins.
+	latest := make(map[string]string)
+
+	for _, r := range reviews {
+		if r.State == "APPROVED" || r.State == "CHANGES_REQUESTED" {
+			latest[r.User.Login] = r.State
+		}
+	}
+
+	result := pull.toPullRequest()
+	result.ReviewState = summarizeReviews(latest, "APPROVED", "CHANGES_REQUESTED")
+
+	return result, nil
+}
+
+// summarizeReviews folds per-reviewer decisions into a single review state.
+func summarizeReviews(latest map[string]string, approved, changesRequested string) string {
+	state := ReviewPending
+
+	for _, decision := range latest {
+		switch decision {
+		case changesRequested:
+			return ReviewChangesRequested
+		case approved:
+			state = ReviewApproved
+		}
+	}
+
+	return state
+}
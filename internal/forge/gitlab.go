@@ -0,0 +1,111 @@
+package forge
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+type gitLab struct {
+	client *client
+}
+
+type gitLabMergeRequest struct {
+	IID         int    `json:"iid"`
+	WebURL      string `json:"web_url"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	State       string `json:"state"`
+	Draft       bool   `json:"draft"`
+}
+
+func (m gitLabMergeRequest) toPullRequest() *PullRequest {
+	state := m.State
+
+	switch m.State {
+	case "opened":
+		state = StateOpen
+	case "locked":
+		state = StateClosed
+	}
+
+	return &PullRequest{
+		Number: m.IID,
+		URL:    m.WebURL,
+		Title:  m.Title,
+		Body:   m.Description,
+		State:  state,
+		Draft:  m.Draft,
+	}
+}
+
+func projectPath(repo RepoRef) string {
+	return "/projects/" + url.PathEscape(repo.Path())
+}
+
+func (g *gitLab) FindPullRequest(ctx context.Context, repo RepoRef, head string) (*PullRequest, error) {
+	query := url.Values{"source_branch": {head}, "state": {"opened"}}
+
+	var mrs []gitLabMergeRequest
+	if err := g.client.do(ctx, http.MethodGet, projectPath(repo)+"/merge_requests?"+query.Encode(), nil, &mrs); err != nil {
+		return nil, err
+	}
+
+	if len(mrs) == 0 {
+		return nil, nil
+	}
+
+	return mrs[0].toPullRequest(), nil
+}
+
+func (g *gitLab) CreatePullRequest(ctx context.Context, repo RepoRef, req PullRequestRequest) (*PullRequest, error) {
+	payload := map[string]any{
+		"title":         draftTitle(req, "Draft:"),
+		"description":   req.Body,
+		"source_branch": req.Head,
+		"target_branch": req.Base,
+	}
+
+	var mr gitLabMergeRequest
+	if err := g.client.do(ctx, http.MethodPost, projectPath(repo)+"/merge_requests", payload, &mr); err != nil {
+		return nil, err
+	}
+
+	return mr.toPullRequest(), nil
+}
+
+func (g *gitLab) UpdatePullRequest(ctx context.Context, repo RepoRef, number int, req PullRequestRequest) (*PullRequest, error) {
+	payload := map[string]any{"title": draftTitle(req, "Draft:"), "description": req.Body}
+
+	var mr gitLabMergeRequest
+	if err := g.client.do(ctx, http.MethodPut, fmt.Sprintf("%s/merge_requests/%d", projectPath(repo), number), payload, &mr); err != nil {
+		return nil, err
+	}
+
+	return mr.toPullRequest(), nil
+}
+
+func (g *gitLab) GetPullRequest(ctx context.Context, repo RepoRef, number int) (*PullRequest, error) {
+	var mr gitLabMergeRequest
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/merge_requests/%d", projectPath(repo), number), nil, &mr); err != nil {
+		return nil, err
+	}
+
+	var approvals struct {
+		Approved bool `json:"approved"`
+	}
+
+	if err := g.client.do(ctx, http.MethodGet, fmt.Sprintf("%s/merge_requests/%d/approvals", projectPath(repo), number), nil, &approvals); err != nil {
+		return nil, err
+	}
+
+	result := mr.toPullRequest()
+
+	result.ReviewState = ReviewPending
+	if approvals.Approved {
+		result.ReviewState = ReviewApproved
+	}
+
+	return result, nil
+}
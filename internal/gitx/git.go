@@ -2,12 +2,19 @@
 package gitx
 
 import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
@@ -65,6 +72,70 @@ func (g *GitEngine) CreateWorktree(repoName, worktreePath, branchName string) er
 	return nil
 }
 
+// CreateWorktreeFrom creates a worktree on branchName, checking out the existing local or remote
+// branch when there is one and otherwise creating it from baseRef.
+func (g *GitEngine) CreateWorktreeFrom(repoName, worktreePath, branchName, baseRef string) error {
+	canonicalPath := filepath.Join(g.ProjectsRoot, repoName)
+
+	cmd := exec.Command("git", "clone", canonicalPath, worktreePath) //nolint:gosec // arguments are constructed internally
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git clone failed: %s: %w", string(output), err)
+	}
+
+	return g.CheckoutBranch(worktreePath, branchName, baseRef)
+}
+
+// CheckoutBranch switches a worktree to branchName. Existing local branches and remote-tracking
+// branches on origin are checked out as-is; otherwise the branch is created from baseRef
+// (origin/baseRef when that exists), or from HEAD when baseRef is empty.
+func (g *GitEngine) CheckoutBranch(path, branchName, baseRef string) error {
+	if _, err := g.run(path, "rev-parse", "--verify", "--quiet", "refs/heads/"+branchName); err == nil {
+		_, err = g.run(path, "checkout", branchName)
+		return err
+	}
+
+	if g.HasRemoteBranch(path, "origin", branchName) {
+		_, err := g.run(path, "checkout", "--track", "origin/"+branchName)
+		return err
+	}
+
+	args := []string{"checkout", "-b", branchName}
+
+	if baseRef != "" {
+		start := baseRef
+		if g.HasRemoteBranch(path, "origin", baseRef) {
+			start = "origin/" + baseRef
+		}
+
+		args = append(args, start)
+	}
+
+	_, err := g.run(path, args...)
+
+	return err
+}
+
+// CreateDetachedWorktree creates a worktree checked out at a detached ref fetched from sourceURL.
+// The ref may be a branch, tag or any fetchable ref such as refs/pull/123/head.
+func (g *GitEngine) CreateDetachedWorktree(repoName, worktreePath, sourceURL, ref string) error {
+	canonicalPath := filepath.Join(g.ProjectsRoot, repoName)
+
+	cmd := exec.Command("git", "clone", "--no-checkout", canonicalPath, worktreePath) //nolint:gosec // arguments are constructed internally
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git clone failed: %s: %w", string(output), err)
+	}
+
+	if _, err := g.run(worktreePath, "fetch", sourceURL, ref); err != nil {
+		return err
+	}
+
+	if _, err := g.run(worktreePath, "checkout", "--detach", "FETCH_HEAD"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // Status returns isDirty, unpushedCommits, behindRemote, branchName, error
 func (g *GitEngine) Status(path string) (bool, int, int, string, error) {
 	r, err := git.PlainOpen(path)
@@ -90,6 +161,11 @@ func (g *GitEngine) Status(path string) (bool, int, int, string, error) {
 		return isDirty, 0, 0, "", fmt.Errorf("failed to get HEAD: %w", err)
 	}
 
+	// Detached checkouts (review workspaces) have no branch to compare against.
+	if !head.Name().IsBranch() {
+		return isDirty, 0, 0, "HEAD", nil
+	}
+
 	branchName := head.Name().Short()
 
 	// Check unpushed commits
@@ -173,11 +249,32 @@ func (g *GitEngine) Pull(path string) error {
 	return nil
 }
 
-// Push pushes the current branch to its upstream.
-func (g *GitEngine) Push(path, branch string) error {
+// PushOptions configures Push.
+type PushOptions struct {
+	Remote         string // defaults to origin
+	ForceWithLease bool
+	DryRun         bool
+}
+
+// Push pushes branch to the remote and sets it as upstream. An empty branch pushes the
+// current branch to its upstream.
+func (g *GitEngine) Push(path, branch string, opts PushOptions) error {
+	remote := opts.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+
 	args := []string{"-C", path, "push"}
+	if opts.ForceWithLease {
+		args = append(args, "--force-with-lease")
+	}
+
+	if opts.DryRun {
+		args = append(args, "--dry-run")
+	}
+
 	if branch != "" {
-		args = append(args, "--set-upstream", "origin", branch)
+		args = append(args, "--set-upstream", remote, branch)
 	}
 
 	cmd := exec.Command("git", args...) //nolint:gosec // arguments are constructed internally
@@ -188,6 +285,32 @@ func (g *GitEngine) Push(path, branch string) error {
 	return nil
 }
 
+// CountCommits returns the number of commits reachable from head but not from base.
+func (g *GitEngine) CountCommits(path, base, head string) (int, error) {
+	out, err := g.run(path, "rev-list", "--count", base+".."+head)
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(out)
+}
+
+// CountUnpushedCommits returns the number of commits reachable from HEAD that
+// are not on any remote-tracking branch.
+func (g *GitEngine) CountUnpushedCommits(path string) (int, error) {
+	out, err := g.run(path, "rev-list", "--count", "HEAD", "--not", "--remotes")
+	if err != nil {
+		return 0, err
+	}
+
+	return strconv.Atoi(out)
+}
+
+// RemoteURL returns the URL of a remote of the repository containing path.
+func (g *GitEngine) RemoteURL(path, remote string) (string, error) {
+	return g.run(path, "remote", "get-url", remote)
+}
+
 // List returns a list of repository names in the projects root
 func (g *GitEngine) List() ([]string, error) {
 	entries, err := os.ReadDir(g.ProjectsRoot)
@@ -231,6 +354,555 @@ func (g *GitEngine) Checkout(path, branchName string, create bool) error {
 	return nil
 }
 
+// DefaultBranch returns the branch origin/HEAD points to in a worktree, falling back to "main".
+func (g *GitEngine) DefaultBranch(path string) string {
+	out, err := g.run(path, "symbolic-ref", "--short", "refs/remotes/origin/HEAD")
+	if err != nil || out == "" {
+		return "main"
+	}
+
+	return strings.TrimPrefix(out, "origin/")
+}
+
+// HasRemoteBranch reports whether a remote-tracking branch exists in the worktree.
+func (g *GitEngine) HasRemoteBranch(path, remote, branch string) bool {
+	_, err := g.run(path, "rev-parse", "--verify", "--quiet", fmt.Sprintf("refs/remotes/%s/%s", remote, branch))
+
+	return err == nil
+}
+
+// RevParse resolves a revision to a commit SHA in the worktree.
+func (g *GitEngine) RevParse(path, rev string) (string, error) {
+	return g.run(path, "rev-parse", "--verify", rev+"^{commit}")
+}
+
+// TreeFingerprint identifies the exact content of a worktree: its HEAD commit plus a hash of
+// uncommitted changes and untracked files. Clean worktrees return just the HEAD SHA.
+func (g *GitEngine) TreeFingerprint(path string) (string, error) {
+	head, err := g.RevParse(path, "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	diff, err := g.run(path, "diff", "HEAD", "--binary")
+	if err != nil {
+		return "", err
+	}
+
+	untracked, err := g.run(path, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return "", err
+	}
+
+	if diff == "" && untracked == "" {
+		return head, nil
+	}
+
+	h := sha256.New()
+	_, _ = io.WriteString(h, diff)
+
+	for _, file := range strings.Split(untracked, "\n") {
+		_, _ = io.WriteString(h, "\x00"+file+"\x00")
+
+		data, err := os.ReadFile(filepath.Join(path, file)) //nolint:gosec // file is listed by git inside the worktree
+		if err == nil {
+			_, _ = h.Write(data)
+		}
+	}
+
+	return head + "-dirty-" + hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// LookupCommit resolves an abbreviated or full commit SHA in a repository (worktree or bare).
+// Branch names and other revisions are not resolved.
+func (g *GitEngine) LookupCommit(path, sha string) (string, bool) {
+	if !isHex(sha) || len(sha) < 4 {
+		return "", false
+	}
+
+	full, err := g.run(path, "rev-parse", "--verify", "--quiet", sha+"^{commit}")
+	if err != nil || !strings.HasPrefix(full, strings.ToLower(sha)) {
+		return "", false
+	}
+
+	return full, true
+}
+
+// HasLocalBranch reports whether refs/heads/<branch> exists.
+func (g *GitEngine) HasLocalBranch(path, branch string) bool {
+	_, err := g.run(path, "rev-parse", "--verify", "--quiet", "refs/heads/"+branch)
+
+	return err == nil
+}
+
+// Contains reports whether rev is reachable from ref.
+func (g *GitEngine) Contains(path, ref, rev string) bool {
+	_, err := g.run(path, "merge-base", "--is-ancestor", rev, ref)
+
+	return err == nil
+}
+
+// IsPushed reports whether rev is contained in the remote-tracking branch refs/remotes/<remote>/<branch>.
+func (g *GitEngine) IsPushed(path, rev, remote, branch string) bool {
+	return g.Contains(path, fmt.Sprintf("refs/remotes/%s/%s", remote, branch), rev)
+}
+
+func isHex(s string) bool {
+	for _, c := range s {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", c) {
+			return false
+		}
+	}
+
+	return s != ""
+}
+
+// Commit is a commit listed by Log.
+type Commit struct {
+	SHA     string
+	Author  string
+	Date    time.Time
+	Subject string
+	Body    string
+}
+
+// Log lists the commits reachable from head but not from base, newest first.
+func (g *GitEngine) Log(path, base, head string) ([]Commit, error) {
+	out, err := g.run(path, "log", "--format=%H%x00%an%x00%aI%x00%s%x00%b%x1e", base+".."+head)
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+
+	for _, record := range strings.Split(out, "\x1e") {
+		fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x00", 5)
+		if len(fields) != 5 {
+			continue
+		}
+
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		commits = append(commits, Commit{
+			SHA:     fields[0],
+			Author:  fields[1],
+			Date:    date,
+			Subject: fields[3],
+			Body:    strings.TrimSpace(fields[4]),
+		})
+	}
+
+	return commits, nil
+}
+
+// MergeBase returns the best common ancestor of two revisions.
+func (g *GitEngine) MergeBase(path, a, b string) (string, error) {
+	return g.run(path, "merge-base", a, b)
+}
+
+// Diff returns the diff between rev and the worktree, covering committed and uncommitted
+// changes. Untracked, non-ignored files show up as added: they are marked intent-to-add in
+// a throwaway copy of the index, so the real index is left untouched. Extra arguments such
+// as --stat or --name-only select the format.
+func (g *GitEngine) Diff(path, rev string, extra ...string) (string, error) {
+	var out string
+
+	err := g.withTempIndex(path, func(env []string) error {
+		if _, err := g.runEnv(path, env, "add", "--intent-to-add", "--all"); err != nil {
+			return err
+		}
+
+		var err error
+
+		args := append([]string{"diff", "--no-color"}, extra...)
+		out, err = g.runEnv(path, env, append(args, rev)...)
+
+		return err
+	})
+
+	return out, err
+}
+
+// withTempIndex runs fn with GIT_INDEX_FILE pointing at a copy of the worktree's index, so
+// commands that stage files do not disturb what the user has staged.
+func (g *GitEngine) withTempIndex(path string, fn func(env []string) error) error {
+	index, err := g.run(path, "rev-parse", "--git-path", "index")
+	if err != nil {
+		return err
+	}
+
+	if !filepath.IsAbs(index) {
+		index = filepath.Join(path, index)
+	}
+
+	dir, err := os.MkdirTemp("", "canopy-index-")
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = os.RemoveAll(dir) }()
+
+	tmpIndex := filepath.Join(dir, "index")
+
+	data, err := os.ReadFile(index) //nolint:gosec // path comes from git rev-parse
+	if err == nil {
+		err = os.WriteFile(tmpIndex, data, 0o600)
+	}
+
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("failed to copy index: %w", err)
+	}
+
+	return fn([]string{"GIT_INDEX_FILE=" + tmpIndex})
+}
+
+// FormatPatch writes one patch file per commit in base..HEAD to outDir and returns their paths.
+func (g *GitEngine) FormatPatch(path, base, outDir string) ([]string, error) {
+	out, err := g.run(path, "format-patch", "--no-color", "-o", outDir, base+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	if out == "" {
+		return nil, nil
+	}
+
+	return strings.Split(out, "\n"), nil
+}
+
+// ApplyMailbox applies patch files with git am using a three-way merge. On failure the
+// partial application is aborted so the worktree is left as it was.
+func (g *GitEngine) ApplyMailbox(path string, patches []string) error {
+	args := append([]string{"am", "--3way"}, patches...)
+	if _, err := g.run(path, args...); err != nil {
+		_, _ = g.run(path, "am", "--abort")
+		return err
+	}
+
+	return nil
+}
+
+// HasStagedChanges reports whether the index differs from HEAD.
+func (g *GitEngine) HasStagedChanges(path string) (bool, error) {
+	return g.hasDiff(path, "diff", "--cached", "--quiet")
+}
+
+// HasTrackedChanges reports whether tracked files differ from HEAD, staged or not.
+func (g *GitEngine) HasTrackedChanges(path string) (bool, error) {
+	return g.hasDiff(path, "diff", "HEAD", "--quiet")
+}
+
+func (g *GitEngine) hasDiff(path string, args ...string) (bool, error) {
+	cmd := exec.Command("git", append([]string{"-C", path}, args...)...) //nolint:gosec // arguments are constructed internally
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return true, nil
+		}
+
+		return false, fmt.Errorf("git %s failed: %w", args[0], err)
+	}
+
+	return false, nil
+}
+
+// Commit records a commit with message, running the repository's git hooks. With all set,
+// modified tracked files are staged first (git commit -a). It returns the new HEAD SHA.
+func (g *GitEngine) Commit(path, message string, all bool) (string, error) {
+	args := []string{"-C", path, "commit", "-m", message}
+	if all {
+		args = append(args, "-a")
+	}
+
+	cmd := exec.Command("git", args...) //nolint:gosec // arguments are constructed internally
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return "", fmt.Errorf("git commit failed: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+
+	return g.RevParse(path, "HEAD")
+}
+
+// UndoCommit removes the last commit, keeping its changes staged.
+func (g *GitEngine) UndoCommit(path string) error {
+	_, err := g.run(path, "reset", "--soft", "HEAD~1")
+
+	return err
+}
+
+// SaveChanges records uncommitted changes, including untracked files, as a commit shaped like
+// one from `git stash push --include-untracked` and returns its SHA. It is assembled from
+// `git stash create` and a separate index for the untracked files, so the worktree, the index
+// and the stash list are never touched. A clean worktree returns an empty SHA.
+func (g *GitEngine) SaveChanges(path, message string) (string, error) {
+	status, err := g.run(path, "status", "--porcelain")
+	if err != nil || status == "" {
+		return "", err
+	}
+
+	head, err := g.RevParse(path, "HEAD")
+	if err != nil {
+		return "", err
+	}
+
+	stash, err := g.run(path, "stash", "create", message)
+	if err != nil {
+		return "", err
+	}
+
+	untracked, err := g.untrackedCommit(path, head)
+	if err != nil || untracked == "" {
+		return stash, err
+	}
+
+	tree, index := head+"^{tree}", stash+"^2"
+	if stash == "" {
+		// Only untracked files changed: the index and worktree trees are both HEAD's.
+		if index, err = g.run(path, "commit-tree", "-p", head, "-m", "index on "+head, tree); err != nil {
+			return "", err
+		}
+	} else {
+		tree = stash + "^{tree}"
+	}
+
+	return g.run(path, "commit-tree", "-p", head, "-p", index, "-p", untracked, "-m", message, tree)
+}
+
+// untrackedCommit commits the untracked, non-ignored files of a worktree into a parentless
+// commit, built in an empty throwaway index, and returns "" when there are none.
+func (g *GitEngine) untrackedCommit(path, head string) (string, error) {
+	out, err := g.run(path, "ls-files", "-z", "--others", "--exclude-standard")
+	if err != nil || out == "" {
+		return "", err
+	}
+
+	files := strings.Split(strings.TrimRight(out, "\x00"), "\x00")
+
+	var sha string
+
+	err = g.withTempIndex(path, func(env []string) error {
+		if _, err := g.runEnv(path, env, "read-tree", "--empty"); err != nil {
+			return err
+		}
+
+		if _, err := g.runEnv(path, env, append([]string{"add", "--"}, files...)...); err != nil {
+			return err
+		}
+
+		tree, err := g.runEnv(path, env, "write-tree")
+		if err != nil {
+			return err
+		}
+
+		sha, err = g.run(path, "commit-tree", "-m", "untracked files on "+head, tree)
+
+		return err
+	})
+
+	return sha, err
+}
+
+// RestoreState resets a worktree to head on branch (detached when branch is "HEAD"),
+// removes untracked files and re-applies the changes saved by SaveChanges when stash is set.
+func (g *GitEngine) RestoreState(path, branch, head, stash string) error {
+	checkout := []string{"checkout", "--force", "-B", branch, head}
+	if branch == "HEAD" || branch == "" {
+		checkout = []string{"checkout", "--force", "--detach", head}
+	}
+
+	if _, err := g.run(path, checkout...); err != nil {
+		return err
+	}
+
+	if _, err := g.run(path, "clean", "-fd"); err != nil {
+		return err
+	}
+
+	if stash != "" {
+		if _, err := g.run(path, "stash", "apply", "--index", stash); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateRef points ref at sha.
+func (g *GitEngine) UpdateRef(path, ref, sha string) error {
+	_, err := g.run(path, "update-ref", ref, sha)
+
+	return err
+}
+
+// DeleteRefs deletes every ref under prefix.
+func (g *GitEngine) DeleteRefs(path, prefix string) error {
+	out, err := g.run(path, "for-each-ref", "--format=%(refname)", prefix)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range strings.Fields(out) {
+		if _, err := g.run(path, "update-ref", "-d", ref); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddedLine is a line added by a commit.
+type AddedLine struct {
+	Commit string
+	File   string
+	Line   int
+	Text   string
+}
+
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,\d+)? @@`)
+
+// AddedLines returns the lines added by each commit in revRange (e.g. "origin/main..HEAD").
+func (g *GitEngine) AddedLines(path, revRange string) ([]AddedLine, error) {
+	out, err := g.run(path, "log", "-p", "-U0", "--no-color", "--no-ext-diff", "--format=%x00%H", revRange)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		lines  []AddedLine
+		commit string
+		file   string
+		number int
+		prev   string
+	)
+
+	for _, line := range strings.Split(out, "\n") {
+		header := strings.HasPrefix(prev, "--- ")
+		prev = line
+
+		switch {
+		case strings.HasPrefix(line, "\x00"):
+			commit, file = strings.TrimPrefix(line, "\x00"), ""
+		case header && strings.HasPrefix(line, "+++ "):
+			file = strings.TrimPrefix(strings.TrimPrefix(line, "+++ "), "b/")
+			if file == "/dev/null" {
+				file = ""
+			}
+		case strings.HasPrefix(line, "@@"):
+			if m := hunkHeader.FindStringSubmatch(line); m != nil {
+				number, _ = strconv.Atoi(m[1])
+			}
+		case strings.HasPrefix(line, "+") && file != "":
+			lines = append(lines, AddedLine{Commit: commit, File: file, Line: number, Text: line[1:]})
+			number++
+		}
+	}
+
+	return lines, nil
+}
+
+// GrepMatch is a line matched by Grep.
+type GrepMatch struct {
+	File string `json:"file"`
+	Line int    `json:"line"`
+	Text string `json:"text"`
+}
+
+// GrepOptions configures Grep.
+type GrepOptions struct {
+	IgnoreCase   bool
+	FixedStrings bool // match the pattern literally instead of as an extended regex
+}
+
+// Grep runs git grep in dir. When rev is set the tree of that revision is searched, which
+// also works in bare repositories; otherwise the worktree's tracked files are searched.
+func (g *GitEngine) Grep(ctx context.Context, dir, pattern, rev string, opts GrepOptions) ([]GrepMatch, error) {
+	args := []string{"-C", dir, "grep", "-n", "-z", "--no-color", "-I"}
+
+	if opts.IgnoreCase {
+		args = append(args, "-i")
+	}
+
+	if opts.FixedStrings {
+		args = append(args, "-F")
+	} else {
+		args = append(args, "-E")
+	}
+
+	args = append(args, "-e", pattern)
+	if rev != "" {
+		args = append(args, rev)
+	}
+
+	cmd := exec.CommandContext(ctx, "git", args...) //nolint:gosec // arguments are constructed internally
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 && stderr.Len() == 0 {
+			return nil, nil // no matches
+		}
+
+		return nil, fmt.Errorf("git grep failed: %s: %w", strings.TrimSpace(stderr.String()), err)
+	}
+
+	var matches []GrepMatch
+
+	for _, line := range strings.Split(strings.TrimSuffix(string(output), "\n"), "\n") {
+		parts := strings.SplitN(line, "\x00", 3)
+		if len(parts) != 3 {
+			continue
+		}
+
+		num, err := strconv.Atoi(parts[1])
+		if err != nil {
+			continue
+		}
+
+		file := parts[0]
+		if rev != "" {
+			file = strings.TrimPrefix(file, rev+":")
+		}
+
+		matches = append(matches, GrepMatch{File: file, Line: num, Text: parts[2]})
+	}
+
+	return matches, nil
+}
+
+// HeadBranch returns the branch HEAD points to, or "HEAD" when detached. Works in bare repositories.
+func (g *GitEngine) HeadBranch(path string) string {
+	out, err := g.run(path, "symbolic-ref", "--short", "HEAD")
+	if err != nil || out == "" {
+		return "HEAD"
+	}
+
+	return out
+}
+
+// run executes a git command in dir and returns its trimmed stdout.
+func (g *GitEngine) run(dir string, args ...string) (string, error) {
+	return g.runEnv(dir, nil, args...)
+}
+
+// runEnv is run with extra environment variables for git.
+func (g *GitEngine) runEnv(dir string, env []string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...) //nolint:gosec // arguments are constructed internally
+	if env != nil {
+		cmd.Env = append(os.Environ(), env...)
+	}
+
+	var stderr strings.Builder
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s failed: %s: %w", args[0], strings.TrimSpace(stderr.String()), err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
 func (g *GitEngine) aheadBehindCounts(path, branch string) (int, int, error) {
 	if branch == "" {
 		return 0, 0, fmt.Errorf("branch name is required")
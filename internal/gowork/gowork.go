@@ -0,0 +1,210 @@
+// Package gowork maintains a go.work file spanning the Go modules of a workspace.
+package gowork
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FileName is the Go workspace file written at the workspace root.
+const FileName = "go.work"
+
+const generatedHeader = "// Code generated by canopy. DO NOT EDIT."
+
+// ErrUserManaged is returned when an existing go.work was not generated by canopy.
+var ErrUserManaged = errors.New("go.work exists and is not managed by canopy")
+
+// moduleDepth is how many directories below a repo root nested modules are looked for.
+const moduleDepth = 2
+
+// Discover returns the directories (relative to root, sorted) containing a go.mod, and the
+// highest go version they declare. Each directory of root is a repo; modules are looked for
+// at its top level and up to moduleDepth directories below, so large trees are not walked.
+func Discover(root string) ([]string, string, error) {
+	var (
+		dirs      []string
+		goVersion string
+	)
+
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+
+			name := d.Name()
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "vendor" || name == "testdata" || name == "node_modules" {
+				return filepath.SkipDir
+			}
+
+			// The repo root is depth 1 below the workspace root.
+			if rel, err := filepath.Rel(root, path); err == nil && strings.Count(filepath.ToSlash(rel), "/") > moduleDepth {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if d.Name() != "go.mod" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+
+		dirs = append(dirs, filepath.ToSlash(rel))
+
+		if v := moduleGoVersion(path); compareVersions(v, goVersion) > 0 {
+			goVersion = v
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to scan for go.mod files: %w", err)
+	}
+
+	sort.Strings(dirs)
+
+	return dirs, goVersion, nil
+}
+
+// Render produces go.work content using the given modules.
+func Render(goVersion string, dirs []string) []byte {
+	var b bytes.Buffer
+
+	b.WriteString(generatedHeader + "\n\n")
+
+	if goVersion != "" {
+		fmt.Fprintf(&b, "go %s\n\n", goVersion)
+	}
+
+	b.WriteString("use (\n")
+
+	for _, dir := range dirs {
+		fmt.Fprintf(&b, "\t./%s\n", dir)
+	}
+
+	b.WriteString(")\n")
+
+	return b.Bytes()
+}
+
+// Sync regenerates root/go.work from the modules found under root and returns them.
+// The file is removed when no modules remain. A go.work not generated by canopy is left
+// untouched and ErrUserManaged returned, unless force is set.
+func Sync(root string, force bool) ([]string, error) {
+	path := filepath.Join(root, FileName)
+
+	existing, err := os.ReadFile(path) //nolint:gosec // path is inside the workspace
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read %s: %w", FileName, err)
+	}
+
+	if err == nil && !force && !bytes.HasPrefix(existing, []byte(generatedHeader)) {
+		return nil, ErrUserManaged
+	}
+
+	dirs, goVersion, err := Discover(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(dirs) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return nil, fmt.Errorf("failed to remove %s: %w", FileName, err)
+		}
+
+		_ = os.Remove(path + ".sum")
+
+		return nil, nil
+	}
+
+	content := Render(goVersion, dirs)
+	if bytes.Equal(content, existing) {
+		return dirs, nil
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil { //nolint:gosec // go.work is not sensitive
+		return nil, fmt.Errorf("failed to write %s: %w", FileName, err)
+	}
+
+	return dirs, nil
+}
+
+// Clean removes root/go.work (and go.work.sum) if canopy generated it.
+func Clean(root string) error {
+	path := filepath.Join(root, FileName)
+
+	existing, err := os.ReadFile(path) //nolint:gosec // path is inside the workspace
+	if err != nil || !bytes.HasPrefix(existing, []byte(generatedHeader)) {
+		return nil //nolint:nilerr // nothing canopy generated to clean
+	}
+
+	_ = os.Remove(path + ".sum")
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", FileName, err)
+	}
+
+	return nil
+}
+
+// moduleGoVersion returns the go directive of a go.mod file, or "" if absent.
+func moduleGoVersion(path string) string {
+	f, err := os.Open(path) //nolint:gosec // path comes from the workspace walk
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = f.Close() }()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 2 && fields[0] == "go" {
+			return fields[1]
+		}
+	}
+
+	return ""
+}
+
+// compareVersions compares dotted Go versions numerically; "" sorts lowest.
+func compareVersions(a, b string) int {
+	pa, pb := strings.Split(a, "."), strings.Split(b, ".")
+
+	for i := 0; i < len(pa) || i < len(pb); i++ {
+		var na, nb int
+		if i < len(pa) {
+			na, _ = strconv.Atoi(pa[i])
+		}
+
+		if i < len(pb) {
+			nb, _ = strconv.Atoi(pb[i])
+		}
+
+		if na != nb {
+			if na < nb {
+				return -1
+			}
+
+			return 1
+		}
+	}
+
+	return 0
+}
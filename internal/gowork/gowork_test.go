@@ -0,0 +1,84 @@
+package gowork
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func TestSyncWritesAndRemovesGoWork(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "svc", "go.mod"), "module example.com/svc\n\ngo 1.22\n")
+	writeFile(t, filepath.Join(root, "lib", "go.mod"), "module example.com/lib\n\ngo 1.23.1\n")
+	writeFile(t, filepath.Join(root, "lib", "tools", "go.mod"), "module example.com/lib/tools\n")
+	writeFile(t, filepath.Join(root, "svc", "vendor", "x", "go.mod"), "module x\n")
+	writeFile(t, filepath.Join(root, "svc", ".git", "go.mod"), "module y\n")
+	writeFile(t, filepath.Join(root, "svc", "internal", "fixtures", "deep", "go.mod"), "module z\n")
+
+	dirs, err := Sync(root, false)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if strings.Join(dirs, ",") != "lib,lib/tools,svc" {
+		t.Fatalf("unexpected modules: %v", dirs)
+	}
+
+	content, err := os.ReadFile(filepath.Join(root, FileName))
+	if err != nil {
+		t.Fatalf("go.work not written: %v", err)
+	}
+
+	for _, want := range []string{"go 1.23.1", "\t./lib\n", "\t./lib/tools\n", "\t./svc\n"} {
+		if !strings.Contains(string(content), want) {
+			t.Fatalf("go.work missing %q:\n%s", want, content)
+		}
+	}
+
+	for _, dir := range []string{"svc", "lib"} {
+		if err := os.RemoveAll(filepath.Join(root, dir)); err != nil {
+			t.Fatalf("failed to remove %s: %v", dir, err)
+		}
+	}
+
+	if _, err := Sync(root, false); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(root, FileName)); !os.IsNotExist(err) {
+		t.Fatalf("expected go.work removed, stat err: %v", err)
+	}
+}
+
+func TestSyncLeavesUserGoWork(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "svc", "go.mod"), "module example.com/svc\n")
+	writeFile(t, filepath.Join(root, FileName), "go 1.22\n\nuse ./svc\n")
+
+	if _, err := Sync(root, false); !errors.Is(err, ErrUserManaged) {
+		t.Fatalf("expected ErrUserManaged, got %v", err)
+	}
+
+	if _, err := Sync(root, true); err != nil {
+		t.Fatalf("forced Sync failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(filepath.Join(root, FileName))
+	if !strings.HasPrefix(string(content), generatedHeader) {
+		t.Fatalf("expected forced Sync to take over go.work:\n%s", content)
+	}
+}
@@ -0,0 +1,29 @@
+package linker
+
+import (
+	"errors"
+
+	"github.com/alexisbeaulieu97/canopy/internal/gowork"
+)
+
+// goLinker maintains go.work through the gowork package.
+type goLinker struct{}
+
+func (goLinker) Name() string { return "go" }
+
+func (goLinker) Sync(root string, _ []string) (Result, error) {
+	dirs, err := gowork.Sync(root, false)
+	if errors.Is(err, gowork.ErrUserManaged) {
+		return Result{}, ErrUserManaged
+	}
+
+	if err != nil || len(dirs) == 0 {
+		return Result{}, err
+	}
+
+	return Result{Linker: "go", Repos: dirs, Files: []string{gowork.FileName}}, nil
+}
+
+func (goLinker) Clean(root string) error {
+	return gowork.Clean(root)
+}
@@ -0,0 +1,105 @@
+// Package linker makes sibling repos of a workspace resolve each other locally by generating
+// ecosystem-specific files (go.work, pnpm-workspace.yaml, ...) at the workspace root.
+package linker
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUserManaged is returned when a root file a linker would write already exists and was not
+// generated by canopy.
+var ErrUserManaged = errors.New("file exists and is not managed by canopy")
+
+// Linker links the repos of one ecosystem.
+type Linker interface {
+	// Name identifies the linker in configuration.
+	Name() string
+	// Sync regenerates the linker's root files for the repo directories under root and returns
+	// what it linked. Files are removed when no repo belongs to the ecosystem.
+	Sync(root string, repos []string) (Result, error)
+	// Clean removes any files the linker generated under root.
+	Clean(root string) error
+}
+
+// Result describes what a linker generated.
+type Result struct {
+	Linker string
+	Repos  []string
+	Files  []string
+}
+
+// Names lists the built-in linkers in the order they run.
+var Names = []string{"go", "node", "python"}
+
+// ByName returns the built-in linkers with the given names.
+func ByName(names []string) ([]Linker, error) {
+	linkers := make([]Linker, 0, len(names))
+
+	for _, name := range names {
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "go":
+			linkers = append(linkers, goLinker{})
+		case "node":
+			linkers = append(linkers, nodeLinker{})
+		case "python":
+			linkers = append(linkers, pythonLinker{})
+		default:
+			return nil, fmt.Errorf("unknown linker %q (available: %s)", name, strings.Join(Names, ", "))
+		}
+	}
+
+	return linkers, nil
+}
+
+// fileExists reports whether path exists.
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// writeManaged writes content to path unless an existing file lacks the marker.
+func writeManaged(path string, content []byte, marker string) error {
+	existing, err := os.ReadFile(path) //nolint:gosec // path is inside the workspace
+	if err == nil {
+		if !bytes.Contains(existing, []byte(marker)) {
+			return fmt.Errorf("%s: %w", filepath.Base(path), ErrUserManaged)
+		}
+
+		if bytes.Equal(existing, content) {
+			return nil
+		}
+	}
+
+	if err := os.WriteFile(path, content, 0o644); err != nil { //nolint:gosec // link files are not sensitive
+		return fmt.Errorf("failed to write %s: %w", filepath.Base(path), err)
+	}
+
+	return nil
+}
+
+// removeManaged deletes path if it carries the marker.
+func removeManaged(path, marker string) error {
+	existing, err := os.ReadFile(path) //nolint:gosec // path is inside the workspace
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	if !bytes.Contains(existing, []byte(marker)) {
+		return nil
+	}
+
+	if err := os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove %s: %w", filepath.Base(path), err)
+	}
+
+	return nil
+}
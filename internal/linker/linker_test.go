@@ -0,0 +1,115 @@
+package linker
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", path, err)
+	}
+
+	return string(data)
+}
+
+func TestNodeLinkerSwitchesBetweenPackageManagers(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "ui", "package.json"), `{"name":"ui"}`)
+	writeFile(t, filepath.Join(root, "kit", "package.json"), `{"name":"kit"}`)
+	writeFile(t, filepath.Join(root, "api", "go.mod"), "module api\n")
+
+	linkers, err := ByName([]string{"node"})
+	if err != nil {
+		t.Fatalf("ByName failed: %v", err)
+	}
+
+	node := linkers[0]
+	repos := []string{"api", "kit", "ui"}
+
+	result, err := node.Sync(root, repos)
+	if err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if strings.Join(result.Repos, ",") != "kit,ui" || result.Files[0] != packageJSONFile {
+		t.Fatalf("unexpected result: %+v", result)
+	}
+
+	if content := readFile(t, filepath.Join(root, packageJSONFile)); !strings.Contains(content, `"workspaces": [`) {
+		t.Fatalf("root package.json missing workspaces:\n%s", content)
+	}
+
+	writeFile(t, filepath.Join(root, "ui", "package.json"), `{"name":"ui","packageManager":"pnpm@9.1.0"}`)
+
+	if _, err := node.Sync(root, repos); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if content := readFile(t, filepath.Join(root, pnpmWorkspaceFile)); !strings.Contains(content, `- "kit"`) {
+		t.Fatalf("pnpm-workspace.yaml missing packages:\n%s", content)
+	}
+
+	if fileExists(filepath.Join(root, packageJSONFile)) {
+		t.Fatalf("expected generated package.json to be removed after switching to pnpm")
+	}
+
+	if err := node.Clean(root); err != nil || fileExists(filepath.Join(root, pnpmWorkspaceFile)) {
+		t.Fatalf("Clean did not remove pnpm-workspace.yaml: %v", err)
+	}
+}
+
+func TestLinkersLeaveUserFiles(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "svc", "pyproject.toml"), "[project]\nname = \"svc\"\n")
+	writeFile(t, filepath.Join(root, pythonRequirements), "requests\n")
+
+	linkers, err := ByName([]string{"python"})
+	if err != nil {
+		t.Fatalf("ByName failed: %v", err)
+	}
+
+	if _, err := linkers[0].Sync(root, []string{"svc"}); !errors.Is(err, ErrUserManaged) {
+		t.Fatalf("expected ErrUserManaged, got %v", err)
+	}
+
+	if err := linkers[0].Clean(root); err != nil || readFile(t, filepath.Join(root, pythonRequirements)) != "requests\n" {
+		t.Fatalf("Clean must not remove user files: %v", err)
+	}
+
+	if err := os.Remove(filepath.Join(root, pythonRequirements)); err != nil {
+		t.Fatalf("failed to remove file: %v", err)
+	}
+
+	if _, err := linkers[0].Sync(root, []string{"svc"}); err != nil {
+		t.Fatalf("Sync failed: %v", err)
+	}
+
+	if content := readFile(t, filepath.Join(root, pythonRequirements)); !strings.Contains(content, "-e ./svc\n") {
+		t.Fatalf("requirements missing editable install:\n%s", content)
+	}
+}
+
+func TestByNameRejectsUnknown(t *testing.T) {
+	if _, err := ByName([]string{"go", "cobol"}); err == nil {
+		t.Fatalf("expected unknown linker error")
+	}
+}
@@ -0,0 +1,114 @@
+package linker
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	nodeMarker        = "Generated by canopy"
+	pnpmWorkspaceFile = "pnpm-workspace.yaml"
+	packageJSONFile   = "package.json"
+)
+
+// nodeLinker links JavaScript packages. Workspaces that use pnpm get a pnpm-workspace.yaml;
+// otherwise a private root package.json declares npm/yarn workspaces.
+type nodeLinker struct{}
+
+func (nodeLinker) Name() string { return "node" }
+
+func (l nodeLinker) Sync(root string, repos []string) (Result, error) {
+	var (
+		packages []string
+		usesPnpm bool
+	)
+
+	for _, repo := range repos {
+		dir := filepath.Join(root, repo)
+		if !fileExists(filepath.Join(dir, packageJSONFile)) {
+			continue
+		}
+
+		packages = append(packages, repo)
+
+		if fileExists(filepath.Join(dir, "pnpm-lock.yaml")) || packageManager(dir) == "pnpm" {
+			usesPnpm = true
+		}
+	}
+
+	if len(packages) == 0 {
+		return Result{}, l.Clean(root)
+	}
+
+	if usesPnpm {
+		var b strings.Builder
+
+		fmt.Fprintf(&b, "# %s; changes are overwritten.\npackages:\n", nodeMarker)
+
+		for _, p := range packages {
+			fmt.Fprintf(&b, "  - %q\n", p)
+		}
+
+		if err := writeManaged(filepath.Join(root, pnpmWorkspaceFile), []byte(b.String()), nodeMarker); err != nil {
+			return Result{}, err
+		}
+
+		// Switching package managers must not leave a stale root package.json behind.
+		if err := removeManaged(filepath.Join(root, packageJSONFile), nodeMarker); err != nil {
+			return Result{}, err
+		}
+
+		return Result{Linker: "node", Repos: packages, Files: []string{pnpmWorkspaceFile}}, nil
+	}
+
+	content, err := json.MarshalIndent(map[string]any{
+		"//":         nodeMarker + "; changes are overwritten.",
+		"name":       "canopy-workspace",
+		"private":    true,
+		"workspaces": packages,
+	}, "", "  ")
+	if err != nil {
+		return Result{}, err
+	}
+
+	if err := writeManaged(filepath.Join(root, packageJSONFile), append(content, '\n'), nodeMarker); err != nil {
+		return Result{}, err
+	}
+
+	if err := removeManaged(filepath.Join(root, pnpmWorkspaceFile), nodeMarker); err != nil {
+		return Result{}, err
+	}
+
+	return Result{Linker: "node", Repos: packages, Files: []string{packageJSONFile}}, nil
+}
+
+func (nodeLinker) Clean(root string) error {
+	if err := removeManaged(filepath.Join(root, pnpmWorkspaceFile), nodeMarker); err != nil {
+		return err
+	}
+
+	return removeManaged(filepath.Join(root, packageJSONFile), nodeMarker)
+}
+
+// packageManager returns the tool named by the packageManager field of a package.json.
+func packageManager(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, packageJSONFile)) //nolint:gosec // path is inside the workspace
+	if err != nil {
+		return ""
+	}
+
+	var pkg struct {
+		PackageManager string `json:"packageManager"`
+	}
+
+	if json.Unmarshal(data, &pkg) != nil {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(pkg.PackageManager, "@")
+
+	return name
+}
@@ -0,0 +1,51 @@
+package linker
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	pythonMarker       = "Generated by canopy"
+	pythonRequirements = "requirements-canopy.txt"
+)
+
+// pythonLinker writes a requirements file installing every Python project in editable mode,
+// so `pip install -r requirements-canopy.txt` links sibling repos into one environment.
+type pythonLinker struct{}
+
+func (pythonLinker) Name() string { return "python" }
+
+func (l pythonLinker) Sync(root string, repos []string) (Result, error) {
+	var projects []string
+
+	for _, repo := range repos {
+		dir := filepath.Join(root, repo)
+		if fileExists(filepath.Join(dir, "pyproject.toml")) || fileExists(filepath.Join(dir, "setup.py")) {
+			projects = append(projects, repo)
+		}
+	}
+
+	if len(projects) == 0 {
+		return Result{}, l.Clean(root)
+	}
+
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "# %s; changes are overwritten.\n# pip install -r %s\n", pythonMarker, pythonRequirements)
+
+	for _, p := range projects {
+		fmt.Fprintf(&b, "-e ./%s\n", p)
+	}
+
+	if err := writeManaged(filepath.Join(root, pythonRequirements), []byte(b.String()), pythonMarker); err != nil {
+		return Result{}, err
+	}
+
+	return Result{Linker: "python", Repos: projects, Files: []string{pythonRequirements}}, nil
+}
+
+func (pythonLinker) Clean(root string) error {
+	return removeManaged(filepath.Join(root, pythonRequirements), pythonMarker)
+}
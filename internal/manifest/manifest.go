@@ -0,0 +1,90 @@
+// Package manifest loads declarative workspace manifests (workspace.canopy.yaml).
+package manifest
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileName is the conventional manifest file name checked into meta-repos.
+const FileName = "workspace.canopy.yaml"
+
+// Manifest describes the desired state of a workspace.
+type Manifest struct {
+	ID          string   `yaml:"id"`
+	Branch      string   `yaml:"branch,omitempty"`
+	Description string   `yaml:"description,omitempty"`
+	Labels      []string `yaml:"labels,omitempty"`
+	Repos       []Repo   `yaml:"repos"`
+}
+
+// Repo describes one repository of a manifest.
+type Repo struct {
+	Name   string            `yaml:"name"`             // registry alias, owner/name or URL
+	URL    string            `yaml:"url,omitempty"`    // overrides registry resolution
+	Branch string            `yaml:"branch,omitempty"` // defaults to the workspace branch
+	Base   string            `yaml:"base,omitempty"`   // ref new branches start from
+	Setup  []string          `yaml:"setup,omitempty"`  // commands run after the repo is added
+	Tasks  map[string]string `yaml:"tasks,omitempty"`  // overrides registry tasks for this workspace
+}
+
+// Load reads and validates a manifest file. A directory is resolved to its FileName.
+func Load(path string) (*Manifest, error) {
+	if info, err := os.Stat(path); err == nil && info.IsDir() {
+		path = filepath.Join(path, FileName)
+	}
+
+	data, err := os.ReadFile(path) //nolint:gosec // manifest path is user-provided
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	return Parse(data)
+}
+
+// Parse decodes and validates manifest YAML.
+func Parse(data []byte) (*Manifest, error) {
+	var m Manifest
+
+	if err := yaml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &m, nil
+}
+
+// Validate checks required fields and rejects duplicate repos.
+func (m *Manifest) Validate() error {
+	if strings.TrimSpace(m.ID) == "" {
+		return fmt.Errorf("manifest: id is required")
+	}
+
+	seen := make(map[string]bool)
+
+	for i, r := range m.Repos {
+		if strings.TrimSpace(r.Name) == "" && strings.TrimSpace(r.URL) == "" {
+			return fmt.Errorf("manifest: repos[%d] needs a name or url", i)
+		}
+
+		key := r.Name
+		if key == "" {
+			key = r.URL
+		}
+
+		if seen[key] {
+			return fmt.Errorf("manifest: repo %q listed more than once", key)
+		}
+
+		seen[key] = true
+	}
+
+	return nil
+}
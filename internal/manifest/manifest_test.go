@@ -0,0 +1,58 @@
+package manifest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadDirectoryResolvesFileName(t *testing.T) {
+	dir := t.TempDir()
+	content := `id: PROJ-42
+branch: feature/PROJ-42
+labels: [backend]
+repos:
+  - name: api
+    base: develop
+    setup: ["make deps"]
+  - name: web
+    url: https://github.com/acme/web.git
+`
+
+	if err := os.WriteFile(filepath.Join(dir, FileName), []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	m, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	if m.ID != "PROJ-42" || m.Branch != "feature/PROJ-42" || len(m.Repos) != 2 {
+		t.Fatalf("unexpected manifest: %+v", m)
+	}
+
+	if m.Repos[0].Base != "develop" || len(m.Repos[0].Setup) != 1 || m.Repos[1].URL == "" {
+		t.Fatalf("unexpected repos: %+v", m.Repos)
+	}
+}
+
+func TestParseValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "missing id", content: "repos: [{name: api}]"},
+		{name: "unnamed repo", content: "id: X\nrepos: [{branch: main}]"},
+		{name: "duplicate repo", content: "id: X\nrepos: [{name: api}, {name: api}]"},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse([]byte(tt.content)); err == nil {
+				t.Fatalf("expected validation error")
+			}
+		})
+	}
+}
@@ -0,0 +1,246 @@
+// Package repomanifest reads and writes manifests of the AOSP `repo` tool (default.xml).
+package repomanifest
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Manifest is the subset of the repo manifest format canopy understands.
+type Manifest struct {
+	XMLName  xml.Name  `xml:"manifest"`
+	Remotes  []Remote  `xml:"remote"`
+	Default  *Default  `xml:"default,omitempty"`
+	Projects []Project `xml:"project"`
+}
+
+// Remote is a named fetch base URL.
+type Remote struct {
+	Name     string `xml:"name,attr"`
+	Fetch    string `xml:"fetch,attr"`
+	Revision string `xml:"revision,attr,omitempty"`
+}
+
+// Default supplies the remote and revision for projects that omit them.
+type Default struct {
+	Remote   string `xml:"remote,attr,omitempty"`
+	Revision string `xml:"revision,attr,omitempty"`
+}
+
+// Project is a single repository checkout.
+type Project struct {
+	Name     string `xml:"name,attr"`
+	Path     string `xml:"path,attr,omitempty"`
+	Remote   string `xml:"remote,attr,omitempty"`
+	Revision string `xml:"revision,attr,omitempty"`
+	Upstream string `xml:"upstream,attr,omitempty"`
+}
+
+// ResolvedProject is a project with its remote and revision defaults applied.
+type ResolvedProject struct {
+	Name     string // checkout directory name
+	URL      string
+	Revision string // branch, tag or commit SHA; empty means the remote default
+}
+
+var shaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+// IsCommitSHA reports whether a revision pins an exact commit.
+func IsCommitSHA(revision string) bool {
+	return shaPattern.MatchString(revision)
+}
+
+// Load reads and parses a manifest file.
+func Load(file string) (*Manifest, error) {
+	data, err := os.ReadFile(file) //nolint:gosec // manifest path is user-provided
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var m Manifest
+	if err := xml.Unmarshal(data, &m); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest: %w", err)
+	}
+
+	return &m, nil
+}
+
+// Resolve applies remote and default settings to every project. Relative fetch URLs
+// (e.g. "..") are resolved against manifestURL, the URL of the manifest repository,
+// as the repo tool does.
+func (m *Manifest) Resolve(manifestURL string) ([]ResolvedProject, error) {
+	remotes := make(map[string]Remote, len(m.Remotes))
+	for _, r := range m.Remotes {
+		remotes[r.Name] = r
+	}
+
+	var def Default
+	if m.Default != nil {
+		def = *m.Default
+	}
+
+	projects := make([]ResolvedProject, 0, len(m.Projects))
+
+	for _, p := range m.Projects {
+		remoteName := p.Remote
+		if remoteName == "" {
+			remoteName = def.Remote
+		}
+
+		remote, ok := remotes[remoteName]
+		if !ok {
+			return nil, fmt.Errorf("project %s: unknown remote %q", p.Name, remoteName)
+		}
+
+		fetch, err := resolveFetch(manifestURL, remote)
+		if err != nil {
+			return nil, err
+		}
+
+		revision := p.Revision
+		if revision == "" {
+			revision = remote.Revision
+		}
+
+		if revision == "" {
+			revision = def.Revision
+		}
+
+		dir := p.Path
+		if dir == "" {
+			dir = p.Name
+		}
+
+		projects = append(projects, ResolvedProject{
+			Name:     path.Base(dir),
+			URL:      strings.TrimRight(fetch, "/") + "/" + p.Name,
+			Revision: strings.TrimPrefix(revision, "refs/heads/"),
+		})
+	}
+
+	return projects, nil
+}
+
+// resolveFetch returns the absolute fetch URL of a remote.
+func resolveFetch(manifestURL string, remote Remote) (string, error) {
+	if strings.Contains(remote.Fetch, ":") || strings.HasPrefix(remote.Fetch, "/") {
+		return remote.Fetch, nil
+	}
+
+	if manifestURL == "" {
+		return "", fmt.Errorf("remote %s: relative fetch URL %q needs the manifest repository URL", remote.Name, remote.Fetch)
+	}
+
+	base, err := url.Parse(manifestURL)
+	if err != nil || (base.Scheme == "" && !strings.HasPrefix(base.Path, "/")) {
+		return "", fmt.Errorf("remote %s: cannot resolve relative fetch URL %q against %q", remote.Name, remote.Fetch, manifestURL)
+	}
+
+	ref, err := url.Parse(remote.Fetch)
+	if err != nil {
+		return "", fmt.Errorf("remote %s: invalid fetch URL %q: %w", remote.Name, remote.Fetch, err)
+	}
+
+	return base.ResolveReference(ref).String(), nil
+}
+
+// Builder assembles a manifest, grouping project URLs under shared remotes.
+type Builder struct {
+	manifest Manifest
+	byFetch  map[string]string
+}
+
+// NewBuilder creates an empty manifest builder.
+func NewBuilder() *Builder {
+	return &Builder{byFetch: make(map[string]string)}
+}
+
+// AddProject adds a project checked out at dir, pinned to revision, tracking upstream.
+func (b *Builder) AddProject(dir, repoURL, revision, upstream string) {
+	fetch, name := splitURL(repoURL)
+
+	remote, ok := b.byFetch[fetch]
+	if !ok {
+		remote = b.remoteName(fetch)
+		b.byFetch[fetch] = remote
+		b.manifest.Remotes = append(b.manifest.Remotes, Remote{Name: remote, Fetch: fetch})
+	}
+
+	p := Project{Name: name, Remote: remote, Revision: revision, Upstream: upstream}
+	if dir != name {
+		p.Path = dir
+	}
+
+	b.manifest.Projects = append(b.manifest.Projects, p)
+}
+
+// Marshal renders the manifest as indented XML.
+func (b *Builder) Marshal() ([]byte, error) {
+	out, err := xml.MarshalIndent(b.manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), append(out, '\n')...), nil
+}
+
+func (b *Builder) remoteName(fetch string) string {
+	base := "local"
+	if u, err := url.Parse(fetch); err == nil && u.Host != "" {
+		base = strings.Split(u.Hostname(), ".")[0]
+		if base == "www" || base == "" {
+			base = u.Hostname()
+		}
+	}
+
+	name := base
+
+	for idx := 2; b.hasRemote(name); idx++ {
+		name = fmt.Sprintf("%s-%d", base, idx)
+	}
+
+	return name
+}
+
+func (b *Builder) hasRemote(name string) bool {
+	for _, r := range b.manifest.Remotes {
+		if r.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// splitURL splits a clone URL into a fetch base and project name.
+func splitURL(repoURL string) (string, string) {
+	// scp-style git@host:owner/name becomes an ssh:// fetch base.
+	if !strings.Contains(repoURL, "://") {
+		if at := strings.Index(repoURL, "@"); at >= 0 {
+			if colon := strings.Index(repoURL[at:], ":"); colon >= 0 {
+				return "ssh://" + repoURL[:at+colon], repoURL[at+colon+1:]
+			}
+		}
+
+		return path.Dir(repoURL), path.Base(repoURL)
+	}
+
+	u, err := url.Parse(repoURL)
+	if err != nil {
+		return path.Dir(repoURL), path.Base(repoURL)
+	}
+
+	if u.Host == "" {
+		return u.Scheme + "://" + path.Dir(u.Path), path.Base(u.Path)
+	}
+
+	name := strings.TrimPrefix(u.Path, "/")
+	u.Path = ""
+
+	return u.String(), name
+}
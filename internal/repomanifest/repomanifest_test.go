@@ -0,0 +1,115 @@
+package repomanifest
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveAppliesDefaults(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<manifest>
+  <remote name="aosp" fetch="https://android.googlesource.com/" />
+  <remote name="corp" fetch="ssh://git@git.example.com" revision="develop" />
+  <default remote="aosp" revision="refs/heads/main" />
+  <project name="platform/build" path="build/make" />
+  <project name="tools/lint" remote="corp" />
+  <project name="device/common" revision="0123456789abcdef0123456789abcdef01234567" />
+</manifest>`
+
+	file := filepath.Join(t.TempDir(), "default.xml")
+	if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	m, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	projects, err := m.Resolve("")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	want := []ResolvedProject{
+		{Name: "make", URL: "https://android.googlesource.com/platform/build", Revision: "main"},
+		{Name: "lint", URL: "ssh://git@git.example.com/tools/lint", Revision: "develop"},
+		{Name: "common", URL: "https://android.googlesource.com/device/common", Revision: "0123456789abcdef0123456789abcdef01234567"},
+	}
+
+	for i, p := range projects {
+		if p != want[i] {
+			t.Fatalf("project %d = %+v, want %+v", i, p, want[i])
+		}
+	}
+
+	if !IsCommitSHA(projects[2].Revision) || IsCommitSHA(projects[0].Revision) {
+		t.Fatalf("IsCommitSHA misclassified revisions")
+	}
+}
+
+func TestResolveRelativeFetch(t *testing.T) {
+	m := &Manifest{
+		Remotes:  []Remote{{Name: "origin", Fetch: ".."}},
+		Default:  &Default{Remote: "origin"},
+		Projects: []Project{{Name: "platform/build"}},
+	}
+
+	if _, err := m.Resolve(""); err == nil {
+		t.Fatalf("expected relative fetch URL without a manifest URL to be rejected")
+	}
+
+	projects, err := m.Resolve("https://android.googlesource.com/platform/manifest")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	if want := "https://android.googlesource.com/platform/build"; projects[0].URL != want || projects[0].Name != "build" {
+		t.Fatalf("project = %+v, want URL %s", projects[0], want)
+	}
+}
+
+func TestBuilderRoundTrip(t *testing.T) {
+	b := NewBuilder()
+	b.AddProject("api", "https://github.com/acme/api.git", "1111111111111111111111111111111111111111", "PROJ-1")
+	b.AddProject("web", "git@github.com:acme/web.git", "2222222222222222222222222222222222222222", "PROJ-1")
+	b.AddProject("tools", "https://gitlab.example.com/team/tools.git", "3333333333333333333333333333333333333333", "PROJ-1")
+
+	out, err := b.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	if !strings.Contains(string(out), `upstream="PROJ-1"`) {
+		t.Fatalf("expected upstream attribute in %s", out)
+	}
+
+	file := filepath.Join(t.TempDir(), "pinned.xml")
+	if err := os.WriteFile(file, out, 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	m, err := Load(file)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	projects, err := m.Resolve("")
+	if err != nil {
+		t.Fatalf("Resolve failed: %v", err)
+	}
+
+	want := []string{"https://github.com/acme/api.git", "ssh://git@github.com/acme/web.git", "https://gitlab.example.com/team/tools.git"}
+
+	for i, p := range projects {
+		if p.URL != want[i] || !IsCommitSHA(p.Revision) {
+			t.Fatalf("project %d = %+v, want URL %s", i, p, want[i])
+		}
+	}
+
+	if len(m.Remotes) != 3 || m.Remotes[0].Name != "github" || m.Remotes[2].Name != "gitlab" {
+		t.Fatalf("unexpected remotes: %+v", m.Remotes)
+	}
+}
@@ -0,0 +1,227 @@
+// Package secrets detects credentials in added lines of code before they leave the machine.
+package secrets
+
+import (
+	"bufio"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// AllowlistFile is the per-repo file, at the repo root, listing paths and matches to ignore.
+const AllowlistFile = ".canopy-secrets-allow"
+
+// Rule flags lines matching a pattern.
+type Rule struct {
+	Name    string
+	Pattern *regexp.Regexp
+}
+
+// BuiltinRules are always checked, in addition to the high-entropy check.
+var BuiltinRules = []Rule{
+	{Name: "aws-access-key-id", Pattern: regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA)[0-9A-Z]{16}\b`)},
+	{Name: "aws-secret-access-key", Pattern: regexp.MustCompile(`(?i)aws.{0,20}(?:secret|private).{0,20}[=:]\s*["']?[0-9A-Za-z/+]{40}\b`)},
+	{Name: "private-key", Pattern: regexp.MustCompile(`-----BEGIN (?:[A-Z]+ )?PRIVATE KEY(?: BLOCK)?-----`)},
+}
+
+// A string of n characters carries at most log2(n) bits per character, so the minimum length
+// must leave room above the threshold: 32 characters allow up to 5 bits.
+const (
+	entropyRule      = "high-entropy-string"
+	entropyMinLength = 32
+	entropyThreshold = 4.5 // bits per character; hex strings top out at 4
+)
+
+var entropyToken = regexp.MustCompile(fmt.Sprintf(`[A-Za-z0-9+/_=-]{%d,}`, entropyMinLength))
+
+// Lockfiles hold checksums and integrity hashes that look random, so the high-entropy check
+// skips them. The pattern rules still apply.
+var Lockfiles = []string{
+	"go.sum", "package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml", "bun.lock",
+	"Cargo.lock", "poetry.lock", "Pipfile.lock", "uv.lock", "Gemfile.lock", "composer.lock",
+}
+
+// Line is an added line to scan.
+type Line struct {
+	Commit string
+	File   string
+	Number int
+	Text   string
+}
+
+// Finding is a suspected secret.
+type Finding struct {
+	Commit string `json:"commit,omitempty"`
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Rule   string `json:"rule"`
+	Match  string `json:"match"` // redacted
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s:%d: %s (%s)", f.File, f.Line, f.Rule, f.Match)
+}
+
+// Scanner checks lines against rules and an allowlist.
+type Scanner struct {
+	rules     []Rule
+	allowlist Allowlist
+}
+
+// NewScanner returns a scanner using the built-in rules plus extra.
+func NewScanner(extra []Rule, allowlist Allowlist) *Scanner {
+	return &Scanner{rules: append(append([]Rule{}, BuiltinRules...), extra...), allowlist: allowlist}
+}
+
+// Scan returns the findings in lines, at most one per line.
+func (s *Scanner) Scan(lines []Line) []Finding {
+	var findings []Finding
+
+	for _, l := range lines {
+		if s.allowlist.AllowsFile(l.File) {
+			continue
+		}
+
+		if rule, match, ok := s.match(l.Text, !slices.Contains(Lockfiles, path.Base(l.File))); ok {
+			findings = append(findings, Finding{Commit: l.Commit, File: l.File, Line: l.Number, Rule: rule, Match: redact(match)})
+		}
+	}
+
+	return findings
+}
+
+func (s *Scanner) match(text string, checkEntropy bool) (string, string, bool) {
+	for _, r := range s.rules {
+		if m := r.Pattern.FindString(text); m != "" && !s.allowlist.AllowsMatch(m) {
+			return r.Name, m, true
+		}
+	}
+
+	if !checkEntropy {
+		return "", "", false
+	}
+
+	for _, token := range entropyToken.FindAllString(text, -1) {
+		if Entropy(token) >= entropyThreshold && !s.allowlist.AllowsMatch(token) {
+			return entropyRule, token, true
+		}
+	}
+
+	return "", "", false
+}
+
+// Entropy returns the Shannon entropy of s in bits per character.
+func Entropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+
+	counts := make(map[rune]int)
+	for _, r := range s {
+		counts[r]++
+	}
+
+	var entropy float64
+
+	n := float64(len([]rune(s)))
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+
+	return entropy
+}
+
+// redact keeps the first and last characters of a match so it can be recognised.
+func redact(s string) string {
+	if len(s) <= 8 {
+		return strings.Repeat("*", len(s))
+	}
+
+	return s[:4] + strings.Repeat("*", len(s)-8) + s[len(s)-4:]
+}
+
+// Allowlist suppresses findings. Each line of an allowlist file is a path glob matched
+// against file paths and their base names, or "match:<regex>" matched against the secret.
+// Lines starting with # are comments.
+type Allowlist struct {
+	Paths   []string
+	Matches []*regexp.Regexp
+}
+
+// LoadAllowlist reads an allowlist file. A missing file is an empty allowlist.
+func LoadAllowlist(file string) (Allowlist, error) {
+	var list Allowlist
+
+	f, err := os.Open(file) //nolint:gosec // allowlist path is derived from the workspace
+	if err != nil {
+		if os.IsNotExist(err) {
+			return list, nil
+		}
+
+		return list, err
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+		case strings.HasPrefix(line, "match:"):
+			re, err := regexp.Compile(strings.TrimSpace(strings.TrimPrefix(line, "match:")))
+			if err != nil {
+				return list, fmt.Errorf("%s:%d: invalid regex: %w", file, n, err)
+			}
+
+			list.Matches = append(list.Matches, re)
+		default:
+			if _, err := path.Match(line, ""); err != nil {
+				return list, fmt.Errorf("%s:%d: invalid pattern %q: %w", file, n, line, err)
+			}
+
+			list.Paths = append(list.Paths, line)
+		}
+	}
+
+	return list, scanner.Err()
+}
+
+// AllowsFile reports whether findings in file are ignored.
+func (a Allowlist) AllowsFile(file string) bool {
+	if file == AllowlistFile {
+		return true
+	}
+
+	for _, p := range a.Paths {
+		if ok, _ := path.Match(p, file); ok {
+			return true
+		}
+
+		if ok, _ := path.Match(p, path.Base(file)); ok {
+			return true
+		}
+
+		if strings.HasSuffix(p, "/") && strings.HasPrefix(file, p) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllowsMatch reports whether a matched secret is ignored.
+func (a Allowlist) AllowsMatch(match string) bool {
+	for _, re := range a.Matches {
+		if re.MatchString(match) {
+			return true
+		}
+	}
+
+	return false
+}
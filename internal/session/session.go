@@ -0,0 +1,201 @@
+// Package session manages terminal multiplexer sessions (tmux, zellij) for workspaces.
+package session
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Window is one repo window (tmux) or tab (zellij) of a session.
+type Window struct {
+	Name    string
+	Dir     string
+	Command string // optional startup command
+}
+
+// Multiplexer creates, attaches to and kills named sessions.
+type Multiplexer interface {
+	Exists(name string) bool
+	Create(name string, windows []Window) error
+	Attach(name string) error
+	Kill(name string) error
+}
+
+// New returns the multiplexer for the configured kind ("tmux" when empty).
+func New(kind string) (Multiplexer, error) {
+	switch strings.ToLower(kind) {
+	case "", "tmux":
+		return &Tmux{Binary: "tmux"}, nil
+	case "zellij":
+		return &Zellij{Binary: "zellij"}, nil
+	default:
+		return nil, fmt.Errorf("unknown multiplexer %q: must be 'tmux' or 'zellij'", kind)
+	}
+}
+
+// nameReplacer removes the characters tmux reads as target separators.
+var nameReplacer = strings.NewReplacer(".", "_", ":", "_", " ", "_")
+
+// Name converts a workspace ID into a session name accepted by tmux and zellij.
+func Name(workspaceID string) string {
+	return nameReplacer.Replace(workspaceID)
+}
+
+// Tmux drives sessions through the tmux CLI.
+type Tmux struct {
+	Binary string
+}
+
+// Exists reports whether a session with exactly this name is running.
+func (t *Tmux) Exists(name string) bool {
+	return exec.Command(t.Binary, "has-session", "-t", "="+name).Run() == nil //nolint:gosec // binary is configured internally
+}
+
+// Create starts a detached session with one window per entry.
+func (t *Tmux) Create(name string, windows []Window) error {
+	if len(windows) == 0 {
+		return fmt.Errorf("session %s needs at least one window", name)
+	}
+
+	for i, w := range windows {
+		// Repo names like "api.v2" would otherwise be parsed as a window.pane target.
+		window := nameReplacer.Replace(w.Name)
+
+		var args []string
+		if i == 0 {
+			args = []string{"new-session", "-d", "-s", name, "-n", window, "-c", w.Dir}
+		} else {
+			args = []string{"new-window", "-t", "=" + name + ":", "-n", window, "-c", w.Dir}
+		}
+
+		if err := t.run(args...); err != nil {
+			return err
+		}
+
+		if w.Command != "" {
+			if err := t.run("send-keys", "-t", "="+name+":"+window, w.Command, "Enter"); err != nil {
+				return err
+			}
+		}
+	}
+
+	return t.run("select-window", "-t", "="+name+":"+nameReplacer.Replace(windows[0].Name))
+}
+
+// Attach attaches the terminal to the session, switching client when already inside tmux.
+func (t *Tmux) Attach(name string) error {
+	verb := "attach-session"
+	if os.Getenv("TMUX") != "" {
+		verb = "switch-client"
+	}
+
+	return interactive(t.Binary, verb, "-t", "="+name)
+}
+
+// Kill stops the session if it is running.
+func (t *Tmux) Kill(name string) error {
+	if !t.Exists(name) {
+		return nil
+	}
+
+	return t.run("kill-session", "-t", "="+name)
+}
+
+func (t *Tmux) run(args ...string) error {
+	cmd := exec.Command(t.Binary, args...) //nolint:gosec // binary is configured internally
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("tmux %s failed: %s: %w", args[0], strings.TrimSpace(string(output)), err)
+	}
+
+	return nil
+}
+
+// Zellij drives sessions through the zellij CLI using a generated layout.
+type Zellij struct {
+	Binary string
+}
+
+// Exists reports whether a session with this name is listed.
+func (z *Zellij) Exists(name string) bool {
+	out, err := exec.Command(z.Binary, "list-sessions", "--short", "--no-formatting").Output() //nolint:gosec // binary is configured internally
+	if err != nil {
+		return false
+	}
+
+	for _, line := range strings.Split(string(out), "\n") {
+		if strings.TrimSpace(line) == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Create writes a layout with one tab per window and starts a background session from it.
+func (z *Zellij) Create(name string, windows []Window) error {
+	if len(windows) == 0 {
+		return fmt.Errorf("session %s needs at least one window", name)
+	}
+
+	var b strings.Builder
+
+	b.WriteString("layout {\n")
+
+	for _, w := range windows {
+		fmt.Fprintf(&b, "    tab name=%q cwd=%q {\n", w.Name, w.Dir)
+
+		if w.Command != "" {
+			fmt.Fprintf(&b, "        pane command=\"sh\" {\n            args \"-c\" %q\n        }\n", w.Command+"; exec ${SHELL:-sh}")
+		} else {
+			b.WriteString("        pane\n")
+		}
+
+		b.WriteString("    }\n")
+	}
+
+	b.WriteString("}\n")
+
+	layout := filepath.Join(os.TempDir(), "canopy-"+name+".kdl")
+	if err := os.WriteFile(layout, []byte(b.String()), 0o600); err != nil {
+		return fmt.Errorf("failed to write zellij layout: %w", err)
+	}
+
+	cmd := exec.Command(z.Binary, "attach", "--create-background", name, "options", "--default-layout", layout) //nolint:gosec // binary is configured internally
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("zellij attach failed: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+
+	return nil
+}
+
+// Attach attaches the terminal to the session.
+func (z *Zellij) Attach(name string) error {
+	return interactive(z.Binary, "attach", name)
+}
+
+// Kill stops the session if it is running.
+func (z *Zellij) Kill(name string) error {
+	if !z.Exists(name) {
+		return nil
+	}
+
+	cmd := exec.Command(z.Binary, "kill-session", name) //nolint:gosec // binary is configured internally
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("zellij kill-session failed: %s: %w", strings.TrimSpace(string(output)), err)
+	}
+
+	return nil
+}
+
+// interactive runs a command attached to the current terminal.
+func interactive(binary string, args ...string) error {
+	cmd := exec.Command(binary, args...) //nolint:gosec // binary is configured internally
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	return cmd.Run()
+}
@@ -0,0 +1,217 @@
+// Package tracker fetches issue details from external issue trackers.
+package tracker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+// Provider fetches issues by key.
+type Provider interface {
+	FetchIssue(ctx context.Context, key string) (*domain.Issue, error)
+}
+
+// Client is an HTTP Provider configured with URL templates.
+type Client struct {
+	kind       string
+	apiURL     *template.Template
+	browseURL  *template.Template
+	username   string
+	token      string
+	HTTPClient *http.Client
+}
+
+// templateData is exposed to api_url and browse_url templates.
+type templateData struct {
+	Key     string
+	Project string
+	Number  string
+}
+
+var issueNumberPattern = regexp.MustCompile(`(\d+)$`)
+
+// New creates a Client from tracker configuration.
+func New(cfg config.TrackerConfig) (*Client, error) {
+	apiURL, err := template.New("api_url").Parse(cfg.APIURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid api_url template: %w", err)
+	}
+
+	var browseURL *template.Template
+
+	if cfg.BrowseURL != "" {
+		browseURL, err = template.New("browse_url").Parse(cfg.BrowseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid browse_url template: %w", err)
+		}
+	}
+
+	return &Client{
+		kind:       strings.ToLower(cfg.Type),
+		apiURL:     apiURL,
+		browseURL:  browseURL,
+		username:   cfg.Username,
+		token:      cfg.ResolveToken(),
+		HTTPClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// FetchIssue retrieves the issue identified by key.
+func (c *Client) FetchIssue(ctx context.Context, key string) (*domain.Issue, error) {
+	data := newTemplateData(key)
+
+	url, err := render(c.apiURL, data)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build tracker request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	c.authorize(req)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("tracker request failed: %w", err)
+	}
+
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tracker response: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("tracker returned %s for %s", resp.Status, key)
+	}
+
+	issue, err := c.decode(body)
+	if err != nil {
+		return nil, err
+	}
+
+	issue.Key = key
+
+	if c.browseURL != nil {
+		if browse, err := render(c.browseURL, data); err == nil {
+			issue.URL = browse
+		}
+	}
+
+	return issue, nil
+}
+
+func (c *Client) authorize(req *http.Request) {
+	if c.token == "" {
+		return
+	}
+
+	if c.username != "" {
+		req.SetBasicAuth(c.username, c.token)
+		return
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+}
+
+func (c *Client) decode(body []byte) (*domain.Issue, error) {
+	switch c.kind {
+	case "jira":
+		var payload struct {
+			Key    string `json:"key"`
+			Fields struct {
+				Summary string `json:"summary"`
+				Status  struct {
+					Name string `json:"name"`
+				} `json:"status"`
+			} `json:"fields"`
+		}
+
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode jira issue: %w", err)
+		}
+
+		return &domain.Issue{Title: payload.Fields.Summary, Status: payload.Fields.Status.Name}, nil
+	case "github":
+		var payload struct {
+			Title   string `json:"title"`
+			State   string `json:"state"`
+			HTMLURL string `json:"html_url"`
+		}
+
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return nil, fmt.Errorf("failed to decode github issue: %w", err)
+		}
+
+		return &domain.Issue{Title: payload.Title, Status: payload.State, URL: payload.HTMLURL}, nil
+	default:
+		return nil, fmt.Errorf("unsupported tracker type %q", c.kind)
+	}
+}
+
+func newTemplateData(key string) templateData {
+	data := templateData{Key: key}
+
+	if idx := strings.LastIndex(key, "-"); idx > 0 {
+		data.Project = key[:idx]
+	}
+
+	if m := issueNumberPattern.FindStringSubmatch(key); m != nil {
+		data.Number = m[1]
+	}
+
+	return data
+}
+
+func render(tmpl *template.Template, data templateData) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", tmpl.Name(), err)
+	}
+
+	return buf.String(), nil
+}
+
+// Slugify converts an issue title into a short, path- and branch-safe slug.
+func Slugify(title string) string {
+	const maxLen = 40
+
+	var b strings.Builder
+
+	lastDash := true
+
+	for _, r := range strings.ToLower(title) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			b.WriteRune(r)
+
+			lastDash = false
+		case !lastDash:
+			b.WriteRune('-')
+
+			lastDash = true
+		}
+	}
+
+	slug := strings.Trim(b.String(), "-")
+	if len(slug) > maxLen {
+		slug = strings.Trim(slug[:maxLen], "-")
+	}
+
+	return slug
+}
@@ -0,0 +1,115 @@
+package tracker
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+)
+
+func TestFetchIssueJira(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/issue/PROJ-481" {
+			http.NotFound(w, r)
+			return
+		}
+
+		if user, pass, ok := r.BasicAuth(); !ok || user != "me@example.com" || pass != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"key":"PROJ-481","fields":{"summary":"Fix login redirect","status":{"name":"In Progress"}}}`))
+	}))
+	defer server.Close()
+
+	client, err := New(config.TrackerConfig{
+		Type:      "jira",
+		APIURL:    server.URL + "/rest/api/2/issue/{{.Key}}",
+		BrowseURL: "https://jira.example.com/browse/{{.Key}}",
+		Username:  "me@example.com",
+		Token:     "secret",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	issue, err := client.FetchIssue(context.Background(), "PROJ-481")
+	if err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+
+	if issue.Title != "Fix login redirect" || issue.Status != "In Progress" {
+		t.Fatalf("unexpected issue: %+v", issue)
+	}
+
+	if issue.URL != "https://jira.example.com/browse/PROJ-481" {
+		t.Fatalf("unexpected URL: %s", issue.URL)
+	}
+}
+
+func TestFetchIssueGitHub(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/acme/api/issues/123" || r.Header.Get("Authorization") != "Bearer gh-token" {
+			http.NotFound(w, r)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"title":"Add rate limiting","state":"open","html_url":"https://github.com/acme/api/issues/123"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv("CANOPY_TEST_GH_TOKEN", "gh-token")
+
+	client, err := New(config.TrackerConfig{
+		Type:     "github",
+		APIURL:   server.URL + "/repos/acme/api/issues/{{.Number}}",
+		TokenEnv: "CANOPY_TEST_GH_TOKEN",
+	})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	issue, err := client.FetchIssue(context.Background(), "GH-123")
+	if err != nil {
+		t.Fatalf("FetchIssue failed: %v", err)
+	}
+
+	if issue.Title != "Add rate limiting" || issue.Status != "open" || issue.URL != "https://github.com/acme/api/issues/123" {
+		t.Fatalf("unexpected issue: %+v", issue)
+	}
+}
+
+func TestFetchIssueNotFound(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	client, err := New(config.TrackerConfig{Type: "jira", APIURL: server.URL + "/{{.Key}}"})
+	if err != nil {
+		t.Fatalf("New failed: %v", err)
+	}
+
+	if _, err := client.FetchIssue(context.Background(), "PROJ-1"); err == nil {
+		t.Fatalf("expected error for missing issue")
+	}
+}
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		title string
+		want  string
+	}{
+		{title: "Fix login redirect", want: "fix-login-redirect"},
+		{title: "  [API] Rate-limit /v2 endpoints!! ", want: "api-rate-limit-v2-endpoints"},
+		{title: "", want: ""},
+		{title: "A very long issue title that keeps going well past the limit", want: "a-very-long-issue-title-that-keeps-going"},
+	}
+
+	for _, tt := range tests {
+		if got := Slugify(tt.title); got != tt.want {
+			t.Fatalf("Slugify(%q) = %q, want %q", tt.title, got, tt.want)
+		}
+	}
+}
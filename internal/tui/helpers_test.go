@@ -1,6 +1,11 @@
 package tui
 
-import "testing"
+import (
+	"strings"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
 
 func TestHumanizeBytes(t *testing.T) {
 	tests := []struct {
@@ -27,3 +32,46 @@ func TestHumanizeBytes(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaceItemFilterValue(t *testing.T) {
+	item := workspaceItem{workspace: domain.Workspace{
+		ID:          "PROJ-481",
+		Description: "Fix login redirect",
+		Labels:      []string{"auth", "urgent"},
+	}}
+
+	got := item.FilterValue()
+
+	for _, want := range []string{"PROJ-481", "Fix login redirect", "auth", "urgent"} {
+		if !strings.Contains(got, want) {
+			t.Fatalf("FilterValue() = %q, missing %q", got, want)
+		}
+	}
+}
+
+func TestRenderBadgesCheckState(t *testing.T) {
+	tests := []struct {
+		state domain.CheckState
+		want  string
+	}{
+		{state: domain.CheckFailure, want: "CI FAILING"},
+		{state: domain.CheckPending, want: "CI PENDING"},
+		{state: domain.CheckSuccess, want: "CI PASSING"},
+		{state: domain.CheckNone, want: ""},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(string(tt.state), func(t *testing.T) {
+			got := renderBadges(workspaceItem{loaded: true, checks: tt.state}, 0)
+
+			if tt.want == "" && strings.Contains(got, "CI ") {
+				t.Fatalf("renderBadges() = %q, expected no CI badge", got)
+			}
+
+			if !strings.Contains(got, tt.want) {
+				t.Fatalf("renderBadges() = %q, missing %q", got, tt.want)
+			}
+		})
+	}
+}
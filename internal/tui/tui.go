@@ -2,10 +2,12 @@
 package tui
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -44,6 +46,7 @@ type workspaceItem struct {
 	summary   workspaceSummary
 	err       error
 	loaded    bool
+	checks    domain.CheckState
 }
 
 type workspaceSummary struct {
@@ -55,7 +58,11 @@ type workspaceSummary struct {
 
 func (i workspaceItem) Title() string       { return i.workspace.ID }
 func (i workspaceItem) Description() string { return "" }
-func (i workspaceItem) FilterValue() string { return i.workspace.ID }
+func (i workspaceItem) FilterValue() string {
+	parts := append([]string{i.workspace.ID, i.workspace.Description}, i.workspace.Labels...)
+
+	return strings.Join(parts, " ")
+}
 
 // Model represents the TUI state.
 type Model struct {
@@ -72,6 +79,10 @@ type Model struct {
 	detailView         bool
 	selectedWS         *domain.Workspace
 	wsStatus           *domain.WorkspaceStatus
+	wsNotes            string
+	wsIssue            *domain.Issue
+	wsIssueErr         error
+	wsPullRequests     []domain.PullRequestStatus
 	confirming         bool
 	actionToConfirm    string // "close" | "push"
 	confirmingID       string
@@ -97,11 +108,28 @@ type workspaceStatusErrMsg struct {
 	err error
 }
 
+type workspaceChecksMsg struct {
+	id    string
+	state domain.CheckState
+}
+
 type pushResultMsg struct {
 	id  string
 	err error
 }
 
+type issueMsg struct {
+	id    string
+	issue *domain.Issue
+	err   error
+}
+
+type pullRequestsMsg struct {
+	id       string
+	statuses []domain.PullRequestStatus
+	err      error
+}
+
 type openEditorResultMsg struct {
 	err error
 }
@@ -109,6 +137,7 @@ type openEditorResultMsg struct {
 type workspaceDetailsMsg struct {
 	workspace *domain.Workspace
 	status    *domain.WorkspaceStatus
+	notes     string
 }
 
 // NewModel creates a new TUI model.
@@ -185,6 +214,20 @@ func (m Model) loadWorkspaceStatus(id string) tea.Cmd {
 	}
 }
 
+func (m Model) loadWorkspaceChecks(id string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		checks, err := m.svc.WorkspaceChecks(ctx, id)
+		if err != nil {
+			return workspaceChecksMsg{id: id, state: domain.CheckNone}
+		}
+
+		return workspaceChecksMsg{id: id, state: checks.State}
+	}
+}
+
 // Update handles incoming Tea messages and state transitions.
 func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo // message-driven switch covers multiple event types
 	switch msg := msg.(type) {
@@ -219,6 +262,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo // me
 		var cmds []tea.Cmd
 		for _, it := range msg.items {
 			cmds = append(cmds, m.loadWorkspaceStatus(it.workspace.ID))
+
+			if m.svc.ChecksConfigured() {
+				cmds = append(cmds, m.loadWorkspaceChecks(it.workspace.ID))
+			}
 		}
 
 		return m, tea.Batch(cmds...)
@@ -229,6 +276,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo // me
 		if m.detailView && m.selectedWS != nil && m.selectedWS.ID == msg.id {
 			m.wsStatus = msg.status
 		}
+	case workspaceChecksMsg:
+		m.updateWorkspaceChecks(msg.id, msg.state)
 	case workspaceStatusErrMsg:
 		m.updateWorkspaceSummary(msg.id, nil, msg.err)
 		m.err = msg.err
@@ -245,7 +294,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) { //nolint:gocyclo // me
 	case workspaceDetailsMsg:
 		m.selectedWS = msg.workspace
 		m.wsStatus = msg.status
+		m.wsNotes = msg.notes
 		m.loadingDetail = false
+	case issueMsg:
+		if m.selectedWS != nil && m.selectedWS.ID == msg.id {
+			m.wsIssue = msg.issue
+			m.wsIssueErr = msg.err
+		}
+	case pullRequestsMsg:
+		if m.selectedWS != nil && m.selectedWS.ID == msg.id {
+			m.wsPullRequests = msg.statuses
+			if msg.err != nil {
+				m.err = msg.err
+			}
+		}
 	case openEditorResultMsg:
 		if msg.err != nil {
 			m.err = msg.err
@@ -309,6 +371,25 @@ func (m *Model) updateWorkspaceSummary(id string, status *domain.WorkspaceStatus
 	}
 }
 
+func (m *Model) updateWorkspaceChecks(id string, state domain.CheckState) {
+	for idx, it := range m.allItems {
+		if it.workspace.ID == id {
+			it.checks = state
+			m.allItems[idx] = it
+		}
+	}
+
+	for idx, listItem := range m.list.Items() {
+		ws, ok := listItem.(workspaceItem)
+		if !ok || ws.workspace.ID != id {
+			continue
+		}
+
+		ws.checks = state
+		m.list.SetItem(idx, ws)
+	}
+}
+
 func summarizeStatus(status *domain.WorkspaceStatus) workspaceSummary {
 	summary := workspaceSummary{
 		repoCount: len(status.Repos),
@@ -377,6 +458,10 @@ func (d workspaceDelegate) Render(w io.Writer, m list.Model, index int, listItem
 	title := titleStyle.Render(wsItem.workspace.ID)
 	badges := renderBadges(wsItem, d.staleThreshold)
 
+	if labels := renderLabels(wsItem.workspace.Labels); labels != "" {
+		badges = strings.TrimSpace(badges + " " + labels)
+	}
+
 	secondary := "loading status..."
 	if wsItem.err != nil {
 		secondary = fmt.Sprintf("status error: %s", wsItem.err.Error())
@@ -388,6 +473,10 @@ func (d workspaceDelegate) Render(w io.Writer, m list.Model, index int, listItem
 			humanizeBytes(wsItem.workspace.DiskUsageBytes),
 			lastUpdated,
 		)
+
+		if wsItem.workspace.Description != "" {
+			secondary = wsItem.workspace.Description + " | " + secondary
+		}
 	}
 
 	_, _ = fmt.Fprintf(
@@ -452,9 +541,34 @@ func renderBadges(item workspaceItem, staleThreshold int) string {
 		badges = append(badges, warnBadge.Render("STALE"))
 	}
 
+	switch item.checks { //nolint:exhaustive // no badge without checks
+	case domain.CheckFailure:
+		badges = append(badges, dangerBadge.Render("CI FAILING"))
+	case domain.CheckPending:
+		badges = append(badges, warnBadge.Render("CI PENDING"))
+	case domain.CheckSuccess:
+		badges = append(badges, badgeStyle.
+			BorderForeground(lipgloss.Color("#50FA7B")).
+			Foreground(lipgloss.Color("#50FA7B")).
+			Render("CI PASSING"))
+	}
+
 	return strings.Join(badges, " ")
 }
 
+func renderLabels(labels []string) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	tags := make([]string, 0, len(labels))
+	for _, l := range labels {
+		tags = append(tags, "#"+l)
+	}
+
+	return subtleTextStyle.Render(strings.Join(tags, " "))
+}
+
 func humanizeBytes(size int64) string {
 	const unit = 1024
 	if size < unit {
@@ -541,9 +655,36 @@ func (m Model) loadWorkspaceDetails(id string) tea.Cmd {
 			return err
 		}
 
+		notes, err := m.svc.WorkspaceNotes(id)
+		if err != nil {
+			return err
+		}
+
 		wsCopy := wsItem.workspace
 
-		return workspaceDetailsMsg{workspace: &wsCopy, status: status}
+		return workspaceDetailsMsg{workspace: &wsCopy, status: status, notes: notes}
+	}
+}
+
+func (m Model) loadIssue(id string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+		defer cancel()
+
+		issue, err := m.svc.FetchIssue(ctx, id)
+
+		return issueMsg{id: id, issue: issue, err: err}
+	}
+}
+
+func (m Model) loadPullRequests(id string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		statuses, err := m.svc.PullRequestStatuses(ctx, id)
+
+		return pullRequestsMsg{id: id, statuses: statuses, err: err}
 	}
 }
 
@@ -618,6 +759,25 @@ func (m Model) renderDetailView() string {
 	builder := strings.Builder{}
 	builder.WriteString(fmt.Sprintf("Workspace: %s\n", m.selectedWS.ID))
 	builder.WriteString(fmt.Sprintf("Branch: %s\n", m.selectedWS.BranchName))
+
+	if m.selectedWS.Description != "" {
+		builder.WriteString(fmt.Sprintf("Description: %s\n", m.selectedWS.Description))
+	}
+
+	if len(m.selectedWS.Labels) > 0 {
+		builder.WriteString(fmt.Sprintf("Labels: %s\n", strings.Join(m.selectedWS.Labels, ", ")))
+	}
+
+	if m.wsIssueErr != nil {
+		builder.WriteString(statusWarnStyle.Render(fmt.Sprintf("Issue: unavailable (%v)", m.wsIssueErr)) + "\n")
+	} else if m.wsIssue != nil {
+		builder.WriteString(fmt.Sprintf("Issue: %s [%s]\n", m.wsIssue.Title, m.wsIssue.Status))
+
+		if m.wsIssue.URL != "" {
+			builder.WriteString(subtleTextStyle.Render(m.wsIssue.URL) + "\n")
+		}
+	}
+
 	builder.WriteString(fmt.Sprintf("Disk: %s\n", humanizeBytes(m.selectedWS.DiskUsageBytes)))
 	builder.WriteString(fmt.Sprintf("Last Modified: %s\n\n", relativeTime(m.selectedWS.LastModified)))
 
@@ -649,11 +809,45 @@ func (m Model) renderDetailView() string {
 		}
 	}
 
+	if m.wsNotes != "" {
+		builder.WriteString("\nNotes:\n")
+		builder.WriteString(strings.TrimRight(m.wsNotes, "\n"))
+		builder.WriteString("\n")
+	}
+
+	if len(m.wsPullRequests) > 0 {
+		builder.WriteString("\nPull Requests:\n")
+
+		for _, pr := range m.wsPullRequests {
+			builder.WriteString(fmt.Sprintf("- %-18s #%d %s %s\n", pr.Repo, pr.Number, renderPullRequestState(pr), subtleTextStyle.Render(pr.URL)))
+		}
+	}
+
 	builder.WriteString("\n(Press 'esc' to go back)")
 
 	return builder.String()
 }
 
+func renderPullRequestState(pr domain.PullRequestStatus) string {
+	if pr.Error != "" {
+		return statusDirtyStyle.Render("error: " + pr.Error)
+	}
+
+	state := pr.State
+	if pr.Draft {
+		state += " (draft)"
+	}
+
+	switch pr.ReviewState {
+	case "approved":
+		return statusCleanStyle.Render(state + ", approved")
+	case "changes_requested":
+		return statusDirtyStyle.Render(state + ", changes requested")
+	default:
+		return statusWarnStyle.Render(state + ", review pending")
+	}
+}
+
 func (m Model) closeWorkspace(id string) tea.Cmd {
 	return func() tea.Msg {
 		err := m.svc.CloseWorkspace(id, false)
@@ -667,10 +861,9 @@ func (m Model) closeWorkspace(id string) tea.Cmd {
 
 func (m Model) pushWorkspace(id string) tea.Cmd {
 	return func() tea.Msg {
-		return pushResultMsg{
-			id:  id,
-			err: m.svc.PushWorkspace(id),
-		}
+		_, err := m.svc.PushWorkspace(id, workspaces.PushOptions{})
+
+		return pushResultMsg{id: id, err: err}
 	}
 }
 
@@ -691,7 +884,16 @@ func (m Model) openWorkspace(id string) tea.Cmd {
 		}
 
 		parts := strings.Fields(editor)
-		cmd := exec.Command(parts[0], append(parts[1:], path)...) //nolint:gosec // editor command is user-provided
+
+		// VS Code-family editors open the generated multi-root workspace file instead of the directory.
+		target := path
+		if isCodeEditor(parts[0]) {
+			if file, ok := m.svc.EditorWorkspaceFile(id); ok {
+				target = file
+			}
+		}
+
+		cmd := exec.Command(parts[0], append(parts[1:], target)...) //nolint:gosec // editor command is user-provided
 		cmd.Stdout = os.Stdout
 		cmd.Stderr = os.Stderr
 		cmd.Stdin = os.Stdin
@@ -705,6 +907,16 @@ func (m Model) openWorkspace(id string) tea.Cmd {
 	}
 }
 
+// isCodeEditor reports whether an editor command understands .code-workspace files.
+func isCodeEditor(command string) bool {
+	switch filepath.Base(command) {
+	case "code", "code-insiders", "codium", "cursor", "windsurf":
+		return true
+	default:
+		return false
+	}
+}
+
 func (m Model) handleKey(key string) (Model, tea.Cmd, bool) {
 	if m.detailView {
 		return m.handleDetailKey(key)
@@ -734,6 +946,10 @@ func (m Model) handleDetailKey(key string) (Model, tea.Cmd, bool) {
 	m.loadingDetail = false
 	m.selectedWS = nil
 	m.wsStatus = nil
+	m.wsNotes = ""
+	m.wsIssue = nil
+	m.wsIssueErr = nil
+	m.wsPullRequests = nil
 
 	return m, nil, true
 }
@@ -821,13 +1037,25 @@ func (m Model) handleEnter() (Model, tea.Cmd, bool) {
 	m.loadingDetail = true
 
 	wsCopy := selected.workspace
+	m.selectedWS = &wsCopy
+	remoteCmd := m.loadIssue(wsCopy.ID)
+
+	if len(wsCopy.PullRequests) > 0 {
+		remoteCmd = tea.Batch(remoteCmd, m.loadPullRequests(wsCopy.ID))
+	}
+
 	if cached, ok := m.statusCache[selected.workspace.ID]; ok {
-		return m, func() tea.Msg {
-			return workspaceDetailsMsg{workspace: &wsCopy, status: cached}
-		}, true
+		return m, tea.Batch(func() tea.Msg {
+			notes, err := m.svc.WorkspaceNotes(wsCopy.ID)
+			if err != nil {
+				return err
+			}
+
+			return workspaceDetailsMsg{workspace: &wsCopy, status: cached, notes: notes}
+		}, remoteCmd), true
 	}
 
-	return m, m.loadWorkspaceDetails(selected.workspace.ID), true
+	return m, tea.Batch(m.loadWorkspaceDetails(selected.workspace.ID), remoteCmd), true
 }
 
 func (m Model) handlePushConfirm() (Model, tea.Cmd, bool) {
@@ -14,6 +14,9 @@ import (
 	"github.com/alexisbeaulieu97/canopy/internal/domain"
 )
 
+// NotesFileName is the name of the free-form notes file kept at the workspace root.
+const NotesFileName = "notes.md"
+
 // Engine manages workspaces
 type Engine struct {
 	WorkspacesRoot string
@@ -46,6 +49,15 @@ func (a ArchivedWorkspace) ArchivedAt() time.Time {
 
 // Create creates a new workspace directory and metadata
 func (e *Engine) Create(dirName, id, branchName string, repos []domain.Repo) error {
+	return e.CreateFrom(dirName, domain.Workspace{
+		ID:         id,
+		BranchName: branchName,
+		Repos:      repos,
+	})
+}
+
+// CreateFrom creates a new workspace directory and writes the provided metadata.
+func (e *Engine) CreateFrom(dirName string, workspace domain.Workspace) error {
 	safeDir, err := sanitizeDirName(dirName)
 	if err != nil {
 		return fmt.Errorf("invalid workspace directory: %w", err)
@@ -61,13 +73,6 @@ func (e *Engine) Create(dirName, id, branchName string, repos []domain.Repo) err
 		return fmt.Errorf("failed to create workspace directory: %w", err)
 	}
 
-	// Create metadata file
-	workspace := domain.Workspace{
-		ID:         id,
-		BranchName: branchName,
-		Repos:      repos,
-	}
-
 	metaPath := filepath.Join(path, "workspace.yaml")
 
 	return e.saveMetadata(metaPath, workspace)
@@ -111,6 +116,10 @@ func (e *Engine) Archive(dirName string, workspace domain.Workspace, archivedAt
 		return nil, fmt.Errorf("failed to write archive metadata: %w", err)
 	}
 
+	if err := copyFileIfExists(filepath.Join(e.WorkspacesRoot, safeDir, NotesFileName), filepath.Join(archiveDir, NotesFileName)); err != nil {
+		return nil, fmt.Errorf("failed to archive notes: %w", err)
+	}
+
 	return &ArchivedWorkspace{
 		DirName:  safeDir,
 		Path:     archiveDir,
@@ -118,6 +127,69 @@ func (e *Engine) Archive(dirName string, workspace domain.Workspace, archivedAt
 	}, nil
 }
 
+// RestoreNotes copies the notes stored with an archive back into an active workspace directory.
+func (e *Engine) RestoreNotes(archive *ArchivedWorkspace, dirName string) error {
+	safeDir, err := sanitizeDirName(dirName)
+	if err != nil {
+		return fmt.Errorf("invalid workspace directory: %w", err)
+	}
+
+	return copyFileIfExists(filepath.Join(archive.Path, NotesFileName), filepath.Join(e.WorkspacesRoot, safeDir, NotesFileName))
+}
+
+// ReadNotes returns the notes for a workspace. Missing notes are returned as an empty string.
+func (e *Engine) ReadNotes(dirName string) (string, error) {
+	safeDir, err := sanitizeDirName(dirName)
+	if err != nil {
+		return "", fmt.Errorf("invalid workspace directory: %w", err)
+	}
+
+	data, err := os.ReadFile(filepath.Join(e.WorkspacesRoot, safeDir, NotesFileName)) //nolint:gosec // path is derived from workspace directory
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+
+		return "", fmt.Errorf("failed to read notes: %w", err)
+	}
+
+	return string(data), nil
+}
+
+// WriteNotes replaces the notes for a workspace. Empty content removes the notes file.
+func (e *Engine) WriteNotes(dirName, content string) error {
+	safeDir, err := sanitizeDirName(dirName)
+	if err != nil {
+		return fmt.Errorf("invalid workspace directory: %w", err)
+	}
+
+	path := filepath.Join(e.WorkspacesRoot, safeDir, NotesFileName)
+
+	if content == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to remove notes: %w", err)
+		}
+
+		return nil
+	}
+
+	if err := os.WriteFile(path, []byte(content), 0o640); err != nil { //nolint:gosec // path is constructed internally
+		return fmt.Errorf("failed to write notes: %w", err)
+	}
+
+	return nil
+}
+
+// NotesPath returns the notes file path for a workspace directory.
+func (e *Engine) NotesPath(dirName string) (string, error) {
+	safeDir, err := sanitizeDirName(dirName)
+	if err != nil {
+		return "", fmt.Errorf("invalid workspace directory: %w", err)
+	}
+
+	return filepath.Join(e.WorkspacesRoot, safeDir, NotesFileName), nil
+}
+
 func (e *Engine) saveMetadata(path string, workspace domain.Workspace) error {
 	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o640) //nolint:gosec // path is constructed internally
 	if err != nil {
@@ -345,6 +417,19 @@ func (e *Engine) DeleteArchive(path string) error {
 	return os.RemoveAll(path)
 }
 
+func copyFileIfExists(src, dst string) error {
+	data, err := os.ReadFile(src) //nolint:gosec // paths are constructed internally
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+
+		return err
+	}
+
+	return os.WriteFile(dst, data, 0o640) //nolint:gosec // paths are constructed internally
+}
+
 func sanitizeDirName(name string) (string, error) {
 	cleaned := filepath.Clean(strings.TrimSpace(name))
 	if cleaned == "" || cleaned == "." {
@@ -0,0 +1,250 @@
+package workspaces
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/manifest"
+)
+
+// ApplyPlan is the set of changes needed to converge a workspace to a manifest.
+type ApplyPlan struct {
+	WorkspaceID string
+	Create      bool
+	Add         []domain.Repo
+	Remove      []domain.Repo
+	Switch      []BranchChange
+	Metadata    []string // human-readable metadata changes
+	Desired     domain.Workspace
+}
+
+// BranchChange describes a repo whose checked-out branch differs from the desired one.
+type BranchChange struct {
+	Repo    string
+	From    string
+	To      string
+	BaseRef string
+}
+
+// Empty reports whether the workspace already matches the manifest.
+func (p *ApplyPlan) Empty() bool {
+	return !p.Create && len(p.Add) == 0 && len(p.Remove) == 0 && len(p.Switch) == 0 && len(p.Metadata) == 0
+}
+
+// PlanApply compares a manifest with the current workspace and returns the changes apply would make.
+func (s *Service) PlanApply(m *manifest.Manifest) (*ApplyPlan, error) {
+	desired, err := s.desiredWorkspace(m)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &ApplyPlan{WorkspaceID: m.ID, Desired: desired}
+
+	current, dirName, err := s.findWorkspace(m.ID)
+	if err != nil {
+		plan.Create = true
+		plan.Add = desired.Repos
+
+		return plan, nil //nolint:nilerr // a missing workspace is planned for creation
+	}
+
+	// Start from the current workspace so fields the manifest does not manage (slug, notes,
+	// pull requests, env, review expiry, ...) survive, then lay the managed ones over it.
+	plan.Desired = *current
+	plan.Desired.Repos = nil
+
+	if desired.BranchName != "" && desired.BranchName != current.BranchName {
+		plan.Desired.BranchName = desired.BranchName
+		plan.Metadata = append(plan.Metadata, fmt.Sprintf("branch: %s -> %s", current.BranchName, desired.BranchName))
+	}
+
+	if m.Description != "" && m.Description != current.Description {
+		plan.Desired.Description = m.Description
+		plan.Metadata = append(plan.Metadata, fmt.Sprintf("description: %q -> %q", current.Description, m.Description))
+	}
+
+	if len(m.Labels) > 0 && !slices.Equal(desired.Labels, current.Labels) {
+		plan.Desired.Labels = desired.Labels
+		plan.Metadata = append(plan.Metadata, fmt.Sprintf("labels: [%s] -> [%s]", strings.Join(current.Labels, ", "), strings.Join(desired.Labels, ", ")))
+	}
+
+	existing := make(map[string]domain.Repo, len(current.Repos))
+	for _, r := range current.Repos {
+		existing[r.Name] = r
+	}
+
+	for _, want := range desired.Repos {
+		have, ok := existing[want.Name]
+		if !ok {
+			plan.Add = append(plan.Add, want)
+			plan.Desired.Repos = append(plan.Desired.Repos, want)
+
+			continue
+		}
+
+		if !maps.Equal(have.Tasks, want.Tasks) {
+			plan.Metadata = append(plan.Metadata, fmt.Sprintf("tasks of %s", want.Name))
+		}
+
+		// Keep what the manifest cannot express, such as a detached read-only review checkout.
+		merged := have
+		merged.Branch = want.Branch
+		merged.BaseRef = want.BaseRef
+		merged.Setup = want.Setup
+		merged.Tasks = want.Tasks
+		plan.Desired.Repos = append(plan.Desired.Repos, merged)
+
+		if merged.Ref != "" {
+			continue
+		}
+
+		worktreePath := filepath.Join(s.config.WorkspacesRoot, dirName, want.Name)
+
+		_, _, _, branch, err := s.gitEngine.Status(worktreePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read status of %s: %w", want.Name, err)
+		}
+
+		if target := repoBranch(merged, plan.Desired.BranchName); branch != target {
+			plan.Switch = append(plan.Switch, BranchChange{Repo: want.Name, From: branch, To: target, BaseRef: merged.BaseRef})
+		}
+	}
+
+	for _, r := range current.Repos {
+		if !slices.ContainsFunc(plan.Desired.Repos, func(d domain.Repo) bool { return d.Name == r.Name }) {
+			plan.Remove = append(plan.Remove, r)
+		}
+	}
+
+	return plan, nil
+}
+
+// ApplyOptions configures Apply.
+type ApplyOptions struct {
+	Force          bool // remove repos even with uncommitted changes or unpushed commits
+	OverridePolicy bool // log branch policy violations instead of refusing to apply
+}
+
+// Apply executes a plan produced by PlanApply. Repos are only removed when they have no
+// uncommitted changes or unpushed commits, unless opts.Force is set.
+func (s *Service) Apply(plan *ApplyPlan, opts ApplyOptions) error {
+	if plan.Create {
+		_, err := s.CreateWorkspaceWithOptions(plan.Desired, CreateOptions{OverridePolicy: opts.OverridePolicy})
+
+		return err
+	}
+
+	_, dirName, err := s.findWorkspace(plan.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	branches := workspaceBranches(domain.Workspace{BranchName: plan.Desired.BranchName, Repos: plan.Add})
+	for _, change := range plan.Switch {
+		branches = append(branches, change.To)
+	}
+
+	if err := s.checkBranchPolicy(plan.WorkspaceID, opts.OverridePolicy, branches...); err != nil {
+		return err
+	}
+
+	if !opts.Force {
+		for _, r := range plan.Remove {
+			if err := s.ensureRepoRemovable(dirName, r.Name); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, r := range plan.Remove {
+		if err := s.removeWorktree(dirName, r.Name); err != nil {
+			return err
+		}
+	}
+
+	for _, r := range plan.Add {
+		if err := s.addWorktree(dirName, plan.Desired.BranchName, r); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range plan.Switch {
+		worktreePath := filepath.Join(s.config.WorkspacesRoot, dirName, change.Repo)
+		if err := s.gitEngine.CheckoutBranch(worktreePath, change.To, change.BaseRef); err != nil {
+			return fmt.Errorf("failed to switch %s to %s: %w", change.Repo, change.To, err)
+		}
+	}
+
+	desired := plan.Desired
+
+	if err := s.wsEngine.Save(dirName, desired); err != nil {
+		return fmt.Errorf("failed to update workspace metadata: %w", err)
+	}
+
+	s.refreshWorkspaceFiles(dirName, desired)
+
+	_, err = s.runSetup(dirName, desired.ID, plan.Add, false)
+
+	return err
+}
+
+// desiredWorkspace resolves manifest repos into workspace metadata.
+func (s *Service) desiredWorkspace(m *manifest.Manifest) (domain.Workspace, error) {
+	ws := domain.Workspace{
+		ID:          m.ID,
+		BranchName:  m.Branch,
+		Description: m.Description,
+		Labels:      normalizeLabels(m.Labels),
+	}
+
+	for _, r := range m.Repos {
+		var repo domain.Repo
+
+		if r.URL != "" {
+			repo = domain.Repo{Name: r.Name, URL: r.URL}
+			if repo.Name == "" {
+				repo.Name = repoNameFromURL(r.URL)
+			}
+		} else {
+			resolved, _, err := s.resolveRepoIdentifier(r.Name, true)
+			if err != nil {
+				return domain.Workspace{}, err
+			}
+
+			repo = resolved
+		}
+
+		repo.Branch = r.Branch
+		repo.BaseRef = r.Base
+		repo.Setup = r.Setup
+		repo.Tasks = r.Tasks
+
+		ws.Repos = append(ws.Repos, repo)
+	}
+
+	return ws, nil
+}
+
+func (s *Service) ensureRepoRemovable(dirName, repoName string) error {
+	worktreePath := filepath.Join(s.config.WorkspacesRoot, dirName, repoName)
+
+	isDirty, _, _, _, err := s.gitEngine.Status(worktreePath)
+	if err != nil {
+		return fmt.Errorf("failed to check status of %s: %w", repoName, err)
+	}
+
+	unpushed, err := s.gitEngine.CountUnpushedCommits(worktreePath)
+	if err != nil {
+		return fmt.Errorf("failed to count unpushed commits in %s: %w", repoName, err)
+	}
+
+	if isDirty || unpushed > 0 {
+		return fmt.Errorf("repo %s has uncommitted changes or unpushed commits. Use --force to remove it", repoName)
+	}
+
+	return nil
+}
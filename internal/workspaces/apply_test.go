@@ -0,0 +1,212 @@
+package workspaces
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/manifest"
+)
+
+func TestApplyManifestConverges(t *testing.T) { //nolint:gocyclo // exercises create, no-op and reconcile passes
+	deps := newTestService(t)
+
+	entries := make(map[string]config.RegistryEntry)
+
+	for _, repo := range newCanonicalRepos(t, deps, "api", "web", "docs") {
+		entries[repo.Name] = config.RegistryEntry{Alias: repo.Name, URL: repo.URL}
+	}
+
+	deps.svc.registry = &config.RepoRegistry{Repos: entries}
+
+	apply := func(content string, force bool) (*ApplyPlan, error) {
+		t.Helper()
+
+		m, err := manifest.Parse([]byte(content))
+		if err != nil {
+			t.Fatalf("Parse failed: %v", err)
+		}
+
+		plan, err := deps.svc.PlanApply(m)
+		if err != nil {
+			t.Fatalf("PlanApply failed: %v", err)
+		}
+
+		return plan, deps.svc.Apply(plan, ApplyOptions{Force: force})
+	}
+
+	initial := `id: PROJ-5
+repos:
+  - name: api
+    setup: ["touch .setup-done"]
+  - name: web
+`
+
+	plan, err := apply(initial, false)
+	if err != nil || !plan.Create || len(plan.Add) != 2 {
+		t.Fatalf("expected workspace creation, got %+v (%v)", plan, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(deps.workspacesRoot, "PROJ-5", "api", ".setup-done")); err != nil {
+		t.Fatalf("setup step did not run: %v", err)
+	}
+
+	if plan, err = apply(initial, false); err != nil || !plan.Empty() {
+		t.Fatalf("second apply should be a no-op, got %+v (%v)", plan, err)
+	}
+
+	// Leave an uncommitted change in web so removing it needs --force.
+	webFile := filepath.Join(deps.workspacesRoot, "PROJ-5", "web", "README.md")
+	if err := os.WriteFile(webFile, []byte("changed"), 0o644); err != nil {
+		t.Fatalf("failed to modify file: %v", err)
+	}
+
+	updated := `id: PROJ-5
+repos:
+  - name: api
+    branch: api-feature
+  - name: docs
+`
+
+	if _, err := apply(updated, false); err == nil || !strings.Contains(err.Error(), "web") {
+		t.Fatalf("expected removal of dirty repo to be refused, got %v", err)
+	}
+
+	plan, err = apply(updated, true)
+	if err != nil {
+		t.Fatalf("forced apply failed: %v", err)
+	}
+
+	if len(plan.Add) != 1 || len(plan.Remove) != 1 || len(plan.Switch) != 1 || plan.Switch[0].To != "api-feature" {
+		t.Fatalf("unexpected plan: %+v", plan)
+	}
+
+	if branch := runGitOutput(t, filepath.Join(deps.workspacesRoot, "PROJ-5", "api"), "rev-parse", "--abbrev-ref", "HEAD"); branch != "api-feature" {
+		t.Fatalf("expected api on api-feature, got %s", branch)
+	}
+
+	if _, err := os.Stat(filepath.Join(deps.workspacesRoot, "PROJ-5", "web")); !os.IsNotExist(err) {
+		t.Fatalf("expected web worktree removed, stat err: %v", err)
+	}
+
+	ws, err := deps.svc.GetWorkspace("PROJ-5")
+	if err != nil {
+		t.Fatalf("GetWorkspace failed: %v", err)
+	}
+
+	if len(ws.Repos) != 2 || ws.Repos[1].Name != "docs" || ws.BranchName != "PROJ-5" {
+		t.Fatalf("unexpected metadata: %+v", ws)
+	}
+
+	if plan, err = apply(updated, false); err != nil || !plan.Empty() {
+		t.Fatalf("apply after reconcile should be a no-op, got %+v (%v)", plan, err)
+	}
+}
+
+func TestApplyRefusesToRemoveUnpushedCommits(t *testing.T) {
+	deps := newTestService(t)
+
+	repos := newTestWorkspace(t, deps, domain.Workspace{ID: "PROJ-6", BranchName: "PROJ-6"}, "api", "web")
+
+	entries := make(map[string]config.RegistryEntry)
+	for _, repo := range repos {
+		entries[repo.Name] = config.RegistryEntry{Alias: repo.Name, URL: repo.URL}
+	}
+
+	deps.svc.registry = &config.RepoRegistry{Repos: entries}
+
+	// Commit on the workspace branch, which has never been pushed.
+	worktree := filepath.Join(deps.workspacesRoot, "PROJ-6", "web")
+	if err := os.WriteFile(filepath.Join(worktree, "local.txt"), []byte("local"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	runGit(t, worktree, "add", ".")
+	runGit(t, worktree, "-c", "user.email=test@example.com", "-c", "user.name=Test User", "commit", "-m", "local only")
+
+	m, err := manifest.Parse([]byte("id: PROJ-6\nrepos:\n  - name: api\n"))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	plan, err := deps.svc.PlanApply(m)
+	if err != nil {
+		t.Fatalf("PlanApply failed: %v", err)
+	}
+
+	if err := deps.svc.Apply(plan, ApplyOptions{}); err == nil || !strings.Contains(err.Error(), "unpushed") {
+		t.Fatalf("expected removal of repo with unpushed commits to be refused, got %v", err)
+	}
+
+	if _, err := os.Stat(worktree); err != nil {
+		t.Fatalf("expected web worktree kept: %v", err)
+	}
+
+	if err := deps.svc.Apply(plan, ApplyOptions{Force: true}); err != nil {
+		t.Fatalf("forced apply failed: %v", err)
+	}
+}
+
+func TestApplyKeepsUnmanagedFields(t *testing.T) {
+	deps := newTestService(t)
+
+	entries := make(map[string]config.RegistryEntry)
+
+	for _, repo := range newCanonicalRepos(t, deps, "api", "web") {
+		entries[repo.Name] = config.RegistryEntry{Alias: repo.Name, URL: repo.URL}
+	}
+
+	deps.svc.registry = &config.RepoRegistry{Repos: entries}
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{
+		ID:        "review-7",
+		Slug:      "login-fix",
+		Ephemeral: true,
+		ExpiresAt: &expiresAt,
+		Repos:     []domain.Repo{{Name: "api", URL: entries["api"].URL, Ref: "master", ReadOnly: true}},
+	}); err != nil {
+		t.Fatalf("failed to create review workspace: %v", err)
+	}
+
+	m, err := manifest.Parse([]byte(`id: review-7
+description: Review login fix
+repos:
+  - name: api
+  - name: web
+`))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	plan, err := deps.svc.PlanApply(m)
+	if err != nil {
+		t.Fatalf("PlanApply failed: %v", err)
+	}
+
+	if len(plan.Switch) != 0 || len(plan.Add) != 1 || plan.Add[0].Name != "web" {
+		t.Fatalf("the detached review checkout must not be switched, got %+v", plan)
+	}
+
+	if err := deps.svc.Apply(plan, ApplyOptions{}); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	ws, err := deps.svc.GetWorkspace("review-7")
+	if err != nil {
+		t.Fatalf("GetWorkspace failed: %v", err)
+	}
+
+	if !ws.Ephemeral || ws.ExpiresAt == nil || !ws.ExpiresAt.Equal(expiresAt) || ws.Slug != "login-fix" || ws.Description != "Review login fix" {
+		t.Fatalf("workspace fields lost by apply: %+v", ws)
+	}
+
+	if api := ws.Repos[0]; api.Ref != "master" || !api.ReadOnly {
+		t.Fatalf("read-only repo fields lost by apply: %+v", api)
+	}
+}
@@ -0,0 +1,144 @@
+package workspaces
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/forge"
+)
+
+// ChecksConfigured reports whether any forge is configured to report CI checks.
+func (s *Service) ChecksConfigured() bool {
+	return len(s.config.Forges) > 0
+}
+
+// WorkspaceChecks fetches CI results for the branch HEAD of every repo and aggregates them.
+// Detached checkouts are skipped. Per-repo lookup failures are recorded on the repo as a
+// failure rather than failing the whole workspace, since waiting does not fix them. While
+// any repo reports, repos without checks count as pending, so the workspace is only green
+// once every repo is.
+func (s *Service) WorkspaceChecks(ctx context.Context, workspaceID string) (*domain.WorkspaceChecks, error) {
+	targetWorkspace, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &domain.WorkspaceChecks{ID: targetWorkspace.ID}
+
+	var (
+		states    []domain.CheckState
+		reporting bool
+	)
+
+	for _, repo := range targetWorkspace.Repos {
+		if repo.Ref != "" {
+			continue
+		}
+
+		worktreePath := filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)
+
+		repoChecks := s.repoChecks(ctx, repo, worktreePath, repoBranch(repo, targetWorkspace.BranchName))
+
+		state := repoChecks.State
+		if state == domain.CheckNone {
+			state = domain.CheckPending
+		} else {
+			reporting = true
+		}
+
+		states = append(states, state)
+		result.Repos = append(result.Repos, repoChecks)
+	}
+
+	result.State = domain.CheckNone
+	if reporting {
+		result.State = domain.AggregateCheckStates(states...)
+	}
+
+	return result, nil
+}
+
+func (s *Service) repoChecks(ctx context.Context, repo domain.Repo, worktreePath, branchName string) domain.RepoChecks {
+	result := domain.RepoChecks{Repo: repo.Name, State: domain.CheckNone}
+
+	failed := func(err error) domain.RepoChecks {
+		result.State = domain.CheckFailure
+		result.Error = err.Error()
+
+		return result
+	}
+
+	sha, err := s.gitEngine.RevParse(worktreePath, branchName)
+	if err != nil {
+		return failed(err)
+	}
+
+	result.SHA = sha
+
+	checks, ref, err := s.checksFor(repo.URL)
+	if err != nil {
+		return failed(err)
+	}
+
+	found, err := checks.CommitChecks(ctx, ref, sha)
+	if err != nil {
+		return failed(err)
+	}
+
+	states := make([]domain.CheckState, 0, len(found))
+	for _, c := range found {
+		states = append(states, c.State)
+	}
+
+	result.Checks = found
+	result.State = domain.AggregateCheckStates(states...)
+
+	return result
+}
+
+// WaitForChecks polls workspace CI results until they succeed or fail, or ctx is done.
+// onUpdate, when set, is called with every poll result.
+func (s *Service) WaitForChecks(ctx context.Context, workspaceID string, interval time.Duration, onUpdate func(*domain.WorkspaceChecks)) (*domain.WorkspaceChecks, error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		result, err := s.WorkspaceChecks(ctx, workspaceID)
+		if err != nil {
+			return nil, err
+		}
+
+		if onUpdate != nil {
+			onUpdate(result)
+		}
+
+		// Repos without checks yet usually mean CI has not picked up the push, so keep waiting.
+		if result.State == domain.CheckSuccess || result.State == domain.CheckFailure {
+			return result, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return result, fmt.Errorf("timed out waiting for checks: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// checksFor resolves the checks provider for the forge hosting a repository URL.
+func (s *Service) checksFor(repoURL string) (forge.Checks, forge.RepoRef, error) {
+	f, ref, err := s.forgeFor(repoURL)
+	if err != nil {
+		return nil, forge.RepoRef{}, err
+	}
+
+	checks, ok := f.(forge.Checks)
+	if !ok {
+		return nil, forge.RepoRef{}, fmt.Errorf("the forge for %s does not report checks", ref.Host)
+	}
+
+	return checks, ref, nil
+}
@@ -0,0 +1,137 @@
+package workspaces
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestWaitForChecksUntilGreen(t *testing.T) {
+	deps := newTestService(t)
+
+	var polls atomic.Int32
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/{repo}/commits/{sha}/status", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"statuses":[]}`))
+	})
+	mux.HandleFunc("GET /repos/acme/{repo}/commits/{sha}/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		// web stays pending for the first poll, api is green immediately.
+		if r.PathValue("repo") == "web" && polls.Add(1) == 1 {
+			_, _ = w.Write([]byte(`{"check_runs":[{"name":"build","status":"queued"}]}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"check_runs":[{"name":"build","status":"completed","conclusion":"success"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	deps.svc.config.Forges = []config.ForgeConfig{{Type: "github", Host: "github.com", APIURL: server.URL}}
+
+	repos := newCanonicalRepos(t, deps, "api", "web")
+	for i := range repos {
+		repos[i].URL = "https://github.com/acme/" + repos[i].Name + ".git"
+	}
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-3", Repos: repos}); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	var updates []domain.CheckState
+
+	result, err := deps.svc.WaitForChecks(t.Context(), "PROJ-3", 10*time.Millisecond, func(c *domain.WorkspaceChecks) {
+		updates = append(updates, c.State)
+	})
+	if err != nil {
+		t.Fatalf("WaitForChecks failed: %v", err)
+	}
+
+	if result.State != domain.CheckSuccess || len(updates) != 2 || updates[0] != domain.CheckPending {
+		t.Fatalf("unexpected result %s after updates %v", result.State, updates)
+	}
+
+	for _, repo := range result.Repos {
+		if repo.SHA == "" || repo.Error != "" {
+			t.Fatalf("unexpected repo checks: %+v", repo)
+		}
+	}
+}
+
+func TestWorkspaceChecksWaitsForSilentRepos(t *testing.T) {
+	deps := newTestService(t)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/{repo}/commits/{sha}/status", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"statuses":[]}`))
+	})
+	mux.HandleFunc("GET /repos/acme/{repo}/commits/{sha}/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		// web was just pushed and CI has not reported anything yet.
+		if r.PathValue("repo") == "web" {
+			_, _ = w.Write([]byte(`{"check_runs":[]}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"check_runs":[{"name":"build","status":"completed","conclusion":"success"}]}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	deps.svc.config.Forges = []config.ForgeConfig{{Type: "github", Host: "github.com", APIURL: server.URL}}
+
+	repos := newCanonicalRepos(t, deps, "api", "web", "docs", "tools")
+	for i := range repos[:3] {
+		repos[i].URL = "https://github.com/acme/" + repos[i].Name + ".git"
+	}
+
+	// api tracks its own branch, docs is hosted on a forge that is not configured and tools
+	// is a detached checkout that CI does not build.
+	repos[0].Branch = "api-feature"
+	repos[2].URL = "https://git.example.com/acme/docs.git"
+	repos[3].Ref = "master"
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-3B", Repos: repos}); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	result, err := deps.svc.WorkspaceChecks(t.Context(), "PROJ-3B")
+	if err != nil {
+		t.Fatalf("WorkspaceChecks failed: %v", err)
+	}
+
+	if len(result.Repos) != 3 {
+		t.Fatalf("expected the detached checkout to be skipped, got %+v", result.Repos)
+	}
+
+	if result.State != domain.CheckFailure {
+		t.Fatalf("expected failure while docs cannot be queried, got %s", result.State)
+	}
+
+	if api := result.Repos[0]; api.State != domain.CheckSuccess || api.Error != "" {
+		t.Fatalf("expected api checks on its own branch, got %+v", api)
+	}
+
+	if docs := result.Repos[2]; docs.State != domain.CheckFailure || docs.Error == "" {
+		t.Fatalf("expected a failure for the unconfigured forge, got %+v", docs)
+	}
+
+	if err := deps.svc.RemoveRepoFromWorkspace("PROJ-3B", "docs"); err != nil {
+		t.Fatalf("RemoveRepoFromWorkspace failed: %v", err)
+	}
+
+	result, err = deps.svc.WorkspaceChecks(t.Context(), "PROJ-3B")
+	if err != nil {
+		t.Fatalf("WorkspaceChecks failed: %v", err)
+	}
+
+	if result.State != domain.CheckPending || result.Repos[1].State != domain.CheckNone {
+		t.Fatalf("expected pending while web reports nothing, got %+v", result)
+	}
+}
@@ -0,0 +1,105 @@
+package workspaces
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// CommitOptions configures CommitWorkspace.
+type CommitOptions struct {
+	Message  string
+	All      bool // stage modified tracked files first, like git commit -a
+	Selector RepoSelector
+	Rollback bool // undo the commits already made when one repo fails
+}
+
+// CommitResult is the outcome of committing in one repo.
+type CommitResult struct {
+	Repo       string
+	SHA        string
+	Skipped    bool // nothing to commit
+	RolledBack bool
+	Err        error
+}
+
+// ErrCommitFailed indicates the commit failed in at least one repo.
+var ErrCommitFailed = errors.New("commit failed")
+
+// CommitWorkspace commits with the same message in every selected repo that has staged
+// changes (or any tracked changes with All). Each repo's git hooks run as usual. When a repo
+// fails and Rollback is set, commits already made in other repos are undone with their
+// changes left staged.
+func (s *Service) CommitWorkspace(workspaceID string, opts CommitOptions) ([]CommitResult, error) {
+	if strings.TrimSpace(opts.Message) == "" {
+		return nil, fmt.Errorf("a commit message is required")
+	}
+
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := s.selectRepos(ws, opts.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]CommitResult, 0, len(repos))
+
+	var failed []string
+
+	for _, repo := range repos {
+		if repo.ReadOnly {
+			continue
+		}
+
+		path := filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)
+		result := CommitResult{Repo: repo.Name}
+
+		var changed bool
+		if opts.All {
+			changed, err = s.gitEngine.HasTrackedChanges(path)
+		} else {
+			changed, err = s.gitEngine.HasStagedChanges(path)
+		}
+
+		switch {
+		case err != nil:
+			result.Err = err
+		case !changed:
+			result.Skipped = true
+		default:
+			result.SHA, result.Err = s.gitEngine.Commit(path, opts.Message, opts.All)
+		}
+
+		if result.Err != nil {
+			failed = append(failed, repo.Name)
+		}
+
+		results = append(results, result)
+	}
+
+	if len(failed) == 0 {
+		return results, nil
+	}
+
+	if opts.Rollback {
+		for i, r := range results {
+			if r.SHA == "" {
+				continue
+			}
+
+			path := filepath.Join(s.config.WorkspacesRoot, dirName, r.Repo)
+			if err := s.gitEngine.UndoCommit(path); err != nil {
+				results[i].Err = fmt.Errorf("rollback failed: %w", err)
+				continue
+			}
+
+			results[i].RolledBack = true
+		}
+	}
+
+	return results, fmt.Errorf("%w in %s", ErrCommitFailed, strings.Join(failed, ", "))
+}
@@ -0,0 +1,67 @@
+package workspaces
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestCommitWorkspace(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	newTestWorkspace(t, deps, domain.Workspace{ID: "PROJ-16"}, "api", "web", "docs")
+
+	worktree := func(name string) string { return filepath.Join(deps.workspacesRoot, "PROJ-16", name) }
+
+	for _, name := range []string{"api", "web"} {
+		runGit(t, worktree(name), "config", "user.email", "test@example.com")
+		runGit(t, worktree(name), "config", "user.name", "Test User")
+
+		if err := os.WriteFile(filepath.Join(worktree(name), "README.md"), []byte("renamed field"), 0o600); err != nil {
+			t.Fatalf("failed to modify %s: %v", name, err)
+		}
+	}
+
+	apiHead := runGitOutput(t, worktree("api"), "rev-parse", "HEAD")
+
+	hook := filepath.Join(worktree("web"), ".git", "hooks", "pre-commit")
+	if err := os.WriteFile(hook, []byte("#!/bin/sh\necho 'lint failed' >&2\nexit 1\n"), 0o755); err != nil { //nolint:gosec // hooks must be executable
+		t.Fatalf("failed to install hook: %v", err)
+	}
+
+	results, err := deps.svc.CommitWorkspace("PROJ-16", CommitOptions{Message: "Rename user field", All: true, Rollback: true})
+	if !errors.Is(err, ErrCommitFailed) {
+		t.Fatalf("expected ErrCommitFailed, got %v", err)
+	}
+
+	if len(results) != 3 || !results[0].RolledBack || results[1].Err == nil || !results[2].Skipped {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	if !strings.Contains(results[1].Err.Error(), "lint failed") {
+		t.Fatalf("expected hook output in error, got %v", results[1].Err)
+	}
+
+	if head := runGitOutput(t, worktree("api"), "rev-parse", "HEAD"); head != apiHead {
+		t.Fatalf("expected api commit to be rolled back")
+	}
+
+	if err := os.Remove(hook); err != nil {
+		t.Fatalf("failed to remove hook: %v", err)
+	}
+
+	// api's changes were left staged by the rollback, so a staged-only commit picks them up.
+	results, err = deps.svc.CommitWorkspace("PROJ-16", CommitOptions{Message: "Rename user field"})
+	if err != nil {
+		t.Fatalf("CommitWorkspace failed: %v", err)
+	}
+
+	if results[0].SHA == "" || !results[1].Skipped {
+		t.Fatalf("expected only api to be committed: %+v", results)
+	}
+}
@@ -0,0 +1,172 @@
+package workspaces
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+// Diff formats accepted by WorkspaceDiff.
+const (
+	DiffPatch    = "patch"
+	DiffStat     = "stat"
+	DiffNameOnly = "name-only"
+)
+
+// RepoDiff is the diff of one workspace repo.
+type RepoDiff struct {
+	Repo   string
+	Base   string // ref the diff is taken against
+	Output string // empty when the repo has no changes
+}
+
+// PatchSeries lists the patch files exported from or applied to one repo.
+type PatchSeries struct {
+	Repo    string
+	Patches []string
+}
+
+// WorkspaceDiff diffs every repo of a workspace against the point where its branch left the
+// base (or its upstream branch when upstream is set), including uncommitted changes and
+// untracked files that are not ignored.
+func (s *Service) WorkspaceDiff(workspaceID, format string, upstream bool) ([]RepoDiff, error) {
+	var extra []string
+
+	switch format {
+	case DiffPatch, "":
+	case DiffStat:
+		extra = []string{"--stat"}
+	case DiffNameOnly:
+		extra = []string{"--name-only"}
+	default:
+		return nil, fmt.Errorf("unknown diff format %q", format)
+	}
+
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	diffs := make([]RepoDiff, 0, len(ws.Repos))
+
+	for _, repo := range ws.Repos {
+		path := filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)
+
+		base := s.repoBase(path, repo)
+		if upstream {
+			base = "@{upstream}"
+		}
+
+		from, err := s.gitEngine.MergeBase(path, base, "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the base of %s: %w", repo.Name, err)
+		}
+
+		out, err := s.gitEngine.Diff(path, from, extra...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff %s: %w", repo.Name, err)
+		}
+
+		diffs = append(diffs, RepoDiff{Repo: repo.Name, Base: base, Output: out})
+	}
+
+	return diffs, nil
+}
+
+// FormatPatches exports the commits of each repo since its base as a patch series into
+// outDir/<repo>. Uncommitted changes are not exported.
+func (s *Service) FormatPatches(workspaceID, outDir string) ([]PatchSeries, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// git runs inside each worktree, so a relative directory must be resolved here.
+	outDir, err = filepath.Abs(outDir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve output directory: %w", err)
+	}
+
+	var series []PatchSeries
+
+	for _, repo := range ws.Repos {
+		path := filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)
+
+		from, err := s.gitEngine.MergeBase(path, s.repoBase(path, repo), "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("failed to find the base of %s: %w", repo.Name, err)
+		}
+
+		patches, err := s.gitEngine.FormatPatch(path, from, filepath.Join(outDir, repo.Name))
+		if err != nil {
+			return nil, fmt.Errorf("failed to export patches of %s: %w", repo.Name, err)
+		}
+
+		if len(patches) > 0 {
+			series = append(series, PatchSeries{Repo: repo.Name, Patches: patches})
+		}
+	}
+
+	return series, nil
+}
+
+// ApplyPatches applies a directory written by FormatPatches onto the matching repos of a
+// workspace with git am. Every subdirectory must belong to a workspace repo.
+func (s *Service) ApplyPatches(workspaceID, dir string) ([]PatchSeries, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	dir, err = filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve patch directory: %w", err)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patch directory: %w", err)
+	}
+
+	var series []PatchSeries
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		if !slices.ContainsFunc(ws.Repos, func(r domain.Repo) bool { return r.Name == entry.Name() }) {
+			return nil, fmt.Errorf("patches for %s, which is not part of workspace %s", entry.Name(), workspaceID)
+		}
+
+		patches, err := filepath.Glob(filepath.Join(dir, entry.Name(), "*.patch"))
+		if err != nil || len(patches) == 0 {
+			continue
+		}
+
+		slices.Sort(patches)
+		series = append(series, PatchSeries{Repo: entry.Name(), Patches: patches})
+	}
+
+	for i, ps := range series {
+		path := filepath.Join(s.config.WorkspacesRoot, dirName, ps.Repo)
+		if err := s.gitEngine.ApplyMailbox(path, ps.Patches); err != nil {
+			if i == 0 {
+				return nil, fmt.Errorf("failed to apply patches to %s: %w", ps.Repo, err)
+			}
+
+			applied := make([]string, 0, i)
+			for _, done := range series[:i] {
+				applied = append(applied, done.Repo)
+			}
+
+			return series[:i], fmt.Errorf("failed to apply patches to %s (already applied to %s): %w", ps.Repo, strings.Join(applied, ", "), err)
+		}
+	}
+
+	return series, nil
+}
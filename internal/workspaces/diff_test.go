@@ -0,0 +1,112 @@
+package workspaces
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestWorkspaceDiffAndPatches(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	repos := newCanonicalRepos(t, deps, "api")
+
+	for _, id := range []string{"PROJ-14", "PROJ-15"} {
+		if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: id, Repos: repos}); err != nil {
+			t.Fatalf("failed to create %s: %v", id, err)
+		}
+
+		worktree := filepath.Join(deps.workspacesRoot, id, "api")
+		runGit(t, worktree, "config", "user.email", "test@example.com")
+		runGit(t, worktree, "config", "user.name", "Test User")
+	}
+
+	worktree := filepath.Join(deps.workspacesRoot, "PROJ-14", "api")
+	writeFile := func(name, content string) {
+		if err := os.WriteFile(filepath.Join(worktree, name), []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	writeFile("users.go", "package api\n")
+	runGit(t, worktree, "add", ".")
+	runGit(t, worktree, "commit", "-m", "feat: add users")
+	writeFile("README.md", "hello users")
+	writeFile("notes.md", "untracked")
+
+	diffs, err := deps.svc.WorkspaceDiff("PROJ-14", DiffNameOnly, false)
+	if err != nil {
+		t.Fatalf("WorkspaceDiff failed: %v", err)
+	}
+
+	if len(diffs) != 1 || diffs[0].Output != "README.md\nnotes.md\nusers.go" || diffs[0].Base != "origin/master" {
+		t.Fatalf("unexpected diff: %+v", diffs)
+	}
+
+	if status := runGitOutput(t, worktree, "status", "--porcelain"); !strings.Contains(status, "?? notes.md") {
+		t.Fatalf("diff must not stage untracked files, status:\n%s", status)
+	}
+
+	outDir := t.TempDir()
+
+	series, err := deps.svc.FormatPatches("PROJ-14", outDir)
+	if err != nil {
+		t.Fatalf("FormatPatches failed: %v", err)
+	}
+
+	if len(series) != 1 || len(series[0].Patches) != 1 {
+		t.Fatalf("expected a single patch for api, got %+v", series)
+	}
+
+	if _, err := deps.svc.ApplyPatches("PROJ-15", outDir); err != nil {
+		t.Fatalf("ApplyPatches failed: %v", err)
+	}
+
+	subject := runGitOutput(t, filepath.Join(deps.workspacesRoot, "PROJ-15", "api"), "log", "-1", "--format=%s")
+	if strings.TrimSpace(subject) != "feat: add users" {
+		t.Fatalf("expected the patch to be applied, got %q", subject)
+	}
+}
+
+func TestPatchesResolveRelativeDirs(t *testing.T) {
+	// Not parallel: the relative directory is resolved against the working directory.
+	t.Chdir(t.TempDir())
+
+	deps := newTestService(t)
+	repos := newTestWorkspace(t, deps, domain.Workspace{ID: "PROJ-21"}, "api")
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-22", Repos: repos}); err != nil {
+		t.Fatalf("failed to create PROJ-22: %v", err)
+	}
+
+	for _, id := range []string{"PROJ-21", "PROJ-22"} {
+		worktree := filepath.Join(deps.workspacesRoot, id, "api")
+		runGit(t, worktree, "config", "user.email", "test@example.com")
+		runGit(t, worktree, "config", "user.name", "Test User")
+	}
+
+	worktree := filepath.Join(deps.workspacesRoot, "PROJ-21", "api")
+	if err := os.WriteFile(filepath.Join(worktree, "users.go"), []byte("package api\n"), 0o600); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	runGit(t, worktree, "add", ".")
+	runGit(t, worktree, "commit", "-m", "feat: add users")
+
+	if _, err := deps.svc.FormatPatches("PROJ-21", "patches"); err != nil {
+		t.Fatalf("FormatPatches failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join("patches", "api")); err != nil {
+		t.Fatalf("expected patches under the working directory: %v", err)
+	}
+
+	series, err := deps.svc.ApplyPatches("PROJ-22", "patches")
+	if err != nil || len(series) != 1 {
+		t.Fatalf("ApplyPatches() = %+v, %v", series, err)
+	}
+}
@@ -0,0 +1,57 @@
+package workspaces
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/editorfiles"
+)
+
+// refreshWorkspaceFiles regenerates the files canopy maintains at the workspace root
+// after its repos change.
+func (s *Service) refreshWorkspaceFiles(dirName string, ws domain.Workspace) {
+	s.refreshLinks(dirName, ws)
+	s.refreshEditorFiles(dirName, ws)
+}
+
+// refreshEditorFiles writes the configured editor project files listing every repo as a root.
+func (s *Service) refreshEditorFiles(dirName string, ws domain.Workspace) {
+	if len(s.config.EditorFiles) == 0 {
+		return
+	}
+
+	folders := make([]editorfiles.Folder, 0, len(ws.Repos))
+
+	for _, repo := range ws.Repos {
+		folder := editorfiles.Folder{Path: repo.Name, Name: repo.Name}
+
+		if s.registry != nil {
+			if entry, ok := s.registry.Resolve(repo.Name); ok {
+				folder.Settings = entry.EditorSettings
+			}
+		}
+
+		folders = append(folders, folder)
+	}
+
+	root := filepath.Join(s.config.WorkspacesRoot, dirName)
+	if err := editorfiles.Sync(root, ws.ID, folders, s.config.EditorFiles); err != nil && s.logger != nil {
+		s.logger.Warn("Failed to update editor files", "workspace", ws.ID, "error", err)
+	}
+}
+
+// EditorWorkspaceFile returns the generated VS Code workspace file for a workspace, if present.
+func (s *Service) EditorWorkspaceFile(workspaceID string) (string, bool) {
+	_, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return "", false
+	}
+
+	path := filepath.Join(s.config.WorkspacesRoot, dirName, editorfiles.CodeWorkspaceFile(workspaceID))
+	if _, err := os.Stat(path); err != nil {
+		return "", false
+	}
+
+	return path, true
+}
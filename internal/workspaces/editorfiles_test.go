@@ -0,0 +1,53 @@
+package workspaces
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestEditorFilesTrackWorkspaceRepos(t *testing.T) {
+	deps := newTestService(t)
+	deps.svc.config.EditorFiles = []string{"vscode"}
+
+	entries := make(map[string]config.RegistryEntry)
+
+	for _, repo := range newCanonicalRepos(t, deps, "api", "web") {
+		entries[repo.Name] = config.RegistryEntry{Alias: repo.Name, URL: repo.URL}
+	}
+
+	entries["web"] = config.RegistryEntry{
+		Alias:          "web",
+		URL:            entries["web"].URL,
+		EditorSettings: map[string]any{"typescript.tsdk": "node_modules/typescript/lib"},
+	}
+	deps.svc.registry = &config.RepoRegistry{Repos: entries}
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-4", Repos: []domain.Repo{{Name: "api", URL: entries["api"].URL}}}); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	if err := deps.svc.AddRepoToWorkspace("PROJ-4", "web"); err != nil {
+		t.Fatalf("AddRepoToWorkspace failed: %v", err)
+	}
+
+	file, ok := deps.svc.EditorWorkspaceFile("PROJ-4")
+	if !ok {
+		t.Fatalf("expected generated .code-workspace file")
+	}
+
+	data, err := os.ReadFile(file)
+	if err != nil {
+		t.Fatalf("failed to read %s: %v", file, err)
+	}
+
+	for _, want := range []string{`"path": "api"`, `"path": "web"`, `"typescript.tsdk"`} {
+		if !strings.Contains(string(data), want) {
+			t.Fatalf("%s missing %s:\n%s", filepath.Base(file), want, data)
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package workspaces
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"hash/fnv"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"text/template"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+var trailingNumber = regexp.MustCompile(`(\d+)$`)
+
+// envTemplateData is passed to env value templates.
+type envTemplateData struct {
+	ID     string
+	Slug   string
+	Branch string
+	Dir    string // workspace directory name
+	Path   string // absolute workspace directory
+	Number int    // trailing digits of the ID, 0 when there are none
+	Offset int    // stable value in [0, 1000) derived from the ID, e.g. for ports
+}
+
+// renderEnv renders the configured env templates for a workspace. Values already stored in
+// the workspace metadata take precedence, so rendered values stay stable once created.
+func (s *Service) renderEnv(ws domain.Workspace, dirName string) (map[string]string, error) {
+	if len(s.config.Env) == 0 && len(ws.Env) == 0 {
+		return nil, nil
+	}
+
+	data := envTemplateData{
+		ID:     ws.ID,
+		Slug:   ws.Slug,
+		Branch: ws.BranchName,
+		Dir:    dirName,
+		Path:   filepath.Join(s.config.WorkspacesRoot, dirName),
+		Offset: envOffset(ws.ID),
+	}
+
+	if m := trailingNumber.FindStringSubmatch(ws.ID); m != nil {
+		data.Number, _ = strconv.Atoi(m[1])
+	}
+
+	env := make(map[string]string, len(s.config.Env)+len(ws.Env))
+
+	for _, e := range s.config.Env {
+		tmpl, err := template.New(e.Name).Funcs(config.EnvTemplateFuncs).Parse(e.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template for env %s: %w", e.Name, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tmpl.Execute(&buf, data); err != nil {
+			return nil, fmt.Errorf("failed to render env %s: %w", e.Name, err)
+		}
+
+		env[e.Name] = buf.String()
+	}
+
+	maps.Copy(env, ws.Env)
+
+	return env, nil
+}
+
+func envOffset(id string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(id))
+
+	return int(h.Sum32() % 1000)
+}
+
+// WorkspaceEnv returns the environment of a workspace: CANOPY_WORKSPACE_ID,
+// CANOPY_WORKSPACE_PATH and its rendered env variables.
+func (s *Service) WorkspaceEnv(workspaceID string) (map[string]string, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := s.renderEnv(*ws, dirName)
+	if err != nil {
+		return nil, err
+	}
+
+	if env == nil {
+		env = make(map[string]string, 2)
+	}
+
+	env["CANOPY_WORKSPACE_ID"] = ws.ID
+	env["CANOPY_WORKSPACE_PATH"] = filepath.Join(s.config.WorkspacesRoot, dirName)
+
+	return env, nil
+}
+
+// WorkspaceCommand prepares a command that runs in the workspace directory with the
+// workspace environment applied on top of the current one.
+func (s *Service) WorkspaceCommand(ctx context.Context, workspaceID string, args []string) (*exec.Cmd, error) {
+	if len(args) == 0 {
+		return nil, fmt.Errorf("no command given")
+	}
+
+	env, err := s.WorkspaceEnv(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...) //nolint:gosec // the user asked to run this command
+	cmd.Dir = env["CANOPY_WORKSPACE_PATH"]
+	cmd.Env = os.Environ()
+
+	for _, k := range slices.Sorted(maps.Keys(env)) {
+		cmd.Env = append(cmd.Env, k+"="+env[k])
+	}
+
+	return cmd, nil
+}
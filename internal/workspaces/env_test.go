@@ -0,0 +1,61 @@
+package workspaces
+
+import (
+	"context"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestWorkspaceEnv(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	deps.svc.config.Env = []config.EnvVar{
+		{Name: "DB_NAME", Value: "app_{{.Number}}"},
+		{Name: "PORT", Value: "{{add 3000 .Offset}}"},
+	}
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-42"}); err != nil {
+		t.Fatalf("CreateWorkspaceFrom failed: %v", err)
+	}
+
+	// Values are stored at creation and survive config changes.
+	deps.svc.config.Env[0].Value = "changed"
+
+	env, err := deps.svc.WorkspaceEnv("PROJ-42")
+	if err != nil {
+		t.Fatalf("WorkspaceEnv failed: %v", err)
+	}
+
+	if env["DB_NAME"] != "app_42" {
+		t.Fatalf("DB_NAME = %q, want app_42", env["DB_NAME"])
+	}
+
+	if port, _ := strconv.Atoi(env["PORT"]); port < 3000 || port >= 4000 {
+		t.Fatalf("PORT = %q, want a value in [3000, 4000)", env["PORT"])
+	}
+
+	if want := filepath.Join(deps.workspacesRoot, "PROJ-42"); env["CANOPY_WORKSPACE_PATH"] != want {
+		t.Fatalf("CANOPY_WORKSPACE_PATH = %q, want %q", env["CANOPY_WORKSPACE_PATH"], want)
+	}
+
+	cmd, err := deps.svc.WorkspaceCommand(context.Background(), "PROJ-42", []string{"sh", "-c", "echo $DB_NAME; pwd"})
+	if err != nil {
+		t.Fatalf("WorkspaceCommand failed: %v", err)
+	}
+
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("command failed: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	if len(lines) != 2 || lines[0] != "app_42" || filepath.Base(lines[1]) != "PROJ-42" {
+		t.Fatalf("unexpected command output: %q", out)
+	}
+}
@@ -0,0 +1,259 @@
+package workspaces
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+// RepoSelector narrows the repos of a workspace by name or by registry tag.
+// An empty selector selects every repo.
+type RepoSelector struct {
+	Repos []string
+	Tags  []string
+}
+
+// ForeachOptions configures Foreach.
+type ForeachOptions struct {
+	Selector RepoSelector
+	Command  string // run with sh -c in each worktree
+	Parallel int    // repos run at once, at least 1
+	FailFast bool   // stop starting repos after the first failure
+}
+
+// ForeachResult is the outcome of the command in one repo.
+type ForeachResult struct {
+	Repo     string
+	ExitCode int // -1 when the command could not run or was skipped
+	Duration time.Duration
+	Skipped  bool // not started because an earlier repo failed with FailFast
+	Output   string
+	Err      error
+}
+
+// ErrForeachFailed indicates the command failed in at least one repo.
+var ErrForeachFailed = errors.New("command failed")
+
+// selectRepos returns the workspace repos matching the selector, in workspace order.
+func (s *Service) selectRepos(ws *domain.Workspace, sel RepoSelector) ([]domain.Repo, error) {
+	for _, name := range sel.Repos {
+		if !slices.ContainsFunc(ws.Repos, func(r domain.Repo) bool { return r.Name == name }) {
+			return nil, fmt.Errorf("repository %s is not part of workspace %s", name, ws.ID)
+		}
+	}
+
+	var selected []domain.Repo
+
+	for _, repo := range ws.Repos {
+		if len(sel.Repos) > 0 && !slices.Contains(sel.Repos, repo.Name) {
+			continue
+		}
+
+		if len(sel.Tags) > 0 && !s.repoHasTag(repo, sel.Tags) {
+			continue
+		}
+
+		selected = append(selected, repo)
+	}
+
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no repositories of workspace %s match the selection", ws.ID)
+	}
+
+	return selected, nil
+}
+
+// repoHasTag reports whether the repo's registry entry carries any of the tags.
+func (s *Service) repoHasTag(repo domain.Repo, tags []string) bool {
+	entry, ok := s.registryEntry(repo)
+	if !ok {
+		return false
+	}
+
+	for _, t := range tags {
+		if slices.ContainsFunc(entry.Tags, func(et string) bool { return strings.EqualFold(et, t) }) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Foreach runs a shell command in each selected worktree of a workspace with the workspace
+// environment plus CANOPY_REPO and CANOPY_REPO_PATH. Output lines are passed to onLine as they
+// are produced (calls are serialized). Results are returned in workspace order; when the command
+// fails anywhere the error wraps ErrForeachFailed.
+func (s *Service) Foreach(ctx context.Context, workspaceID string, opts ForeachOptions, onLine func(repo, line string)) ([]ForeachResult, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := s.selectRepos(ws, opts.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := s.WorkspaceEnv(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	baseEnv := os.Environ()
+	for _, k := range slices.Sorted(maps.Keys(env)) {
+		baseEnv = append(baseEnv, k+"="+env[k])
+	}
+
+	// Cancelling stopCtx only stops new repos from starting; running commands finish.
+	stopCtx, stop := context.WithCancel(ctx)
+	defer stop()
+
+	parallel := max(opts.Parallel, 1)
+	sem := make(chan struct{}, parallel)
+	results := make([]ForeachResult, len(repos))
+
+	var (
+		wg     sync.WaitGroup
+		lineMu sync.Mutex
+	)
+
+	emit := func(repo, line string) {
+		if onLine == nil {
+			return
+		}
+
+		lineMu.Lock()
+		defer lineMu.Unlock()
+
+		onLine(repo, line)
+	}
+
+	for i, repo := range repos {
+		select {
+		case sem <- struct{}{}:
+		case <-stopCtx.Done():
+		}
+
+		if stopCtx.Err() != nil {
+			results[i] = ForeachResult{Repo: repo.Name, Skipped: true, ExitCode: -1}
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(i int, repo domain.Repo) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			path := filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)
+			result := runForeachCommand(ctx, repo.Name, path, opts.Command, baseEnv, emit)
+			results[i] = result
+
+			if result.Err != nil && opts.FailFast {
+				stop()
+			}
+		}(i, repo)
+	}
+
+	wg.Wait()
+
+	failed, skipped := 0, 0
+
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			skipped++
+		case r.Err != nil:
+			failed++
+		}
+	}
+
+	if failed > 0 || skipped > 0 {
+		err := fmt.Errorf("%w in %d of %d repos", ErrForeachFailed, failed, len(results))
+		if skipped > 0 {
+			err = fmt.Errorf("%w (%d skipped)", err, skipped)
+		}
+
+		return results, err
+	}
+
+	return results, nil
+}
+
+func runForeachCommand(ctx context.Context, repo, dir, command string, env []string, emit func(repo, line string)) ForeachResult {
+	result := ForeachResult{Repo: repo}
+	start := time.Now()
+
+	var output bytes.Buffer
+
+	w := &lineWriter{emit: func(line string) {
+		output.WriteString(line + "\n")
+		emit(repo, line)
+	}}
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command) //nolint:gosec // the user asked to run this command
+	cmd.Dir = dir
+	cmd.Env = slices.Concat(env, []string{"CANOPY_REPO=" + repo, "CANOPY_REPO_PATH=" + dir})
+	cmd.Stdout = w
+	cmd.Stderr = w
+	cmd.WaitDelay = time.Second
+
+	result.Err = cmd.Run()
+	w.Flush()
+
+	result.Duration = time.Since(start)
+	result.Output = output.String()
+
+	var exitErr *exec.ExitError
+
+	switch {
+	case result.Err == nil:
+	case errors.As(result.Err, &exitErr) && exitErr.ExitCode() > 0:
+		result.ExitCode = exitErr.ExitCode()
+	default:
+		result.ExitCode = -1
+	}
+
+	return result
+}
+
+// lineWriter splits written bytes into lines.
+type lineWriter struct {
+	buf  []byte
+	emit func(line string)
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+		if idx < 0 {
+			break
+		}
+
+		w.emit(strings.TrimSuffix(string(w.buf[:idx]), "\r"))
+		w.buf = w.buf[idx+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush emits a trailing line without a newline.
+func (w *lineWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.emit(string(w.buf))
+		w.buf = nil
+	}
+}
@@ -0,0 +1,85 @@
+package workspaces
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestForeach(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	deps.svc.registry = &config.RepoRegistry{Repos: map[string]config.RegistryEntry{
+		"api":  {Alias: "api", URL: "file:///api", Tags: []string{"backend"}},
+		"jobs": {Alias: "jobs", URL: "file:///jobs", Tags: []string{"Backend"}},
+		"web":  {Alias: "web", URL: "file:///web", Tags: []string{"frontend"}},
+	}}
+
+	repos := []domain.Repo{{Name: "api"}, {Name: "jobs"}, {Name: "web"}}
+	if err := deps.wsEngine.Create("PROJ-8", "PROJ-8", "PROJ-8", repos); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	for _, r := range repos {
+		mustMkdir(t, filepath.Join(deps.workspacesRoot, "PROJ-8", r.Name))
+	}
+
+	var (
+		mu    sync.Mutex
+		lines []string
+	)
+
+	onLine := func(repo, line string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		lines = append(lines, repo+": "+line)
+	}
+
+	opts := ForeachOptions{
+		Selector: RepoSelector{Tags: []string{"backend"}},
+		Command:  `echo "$CANOPY_REPO $CANOPY_WORKSPACE_ID"; test "$CANOPY_REPO" != jobs`,
+		Parallel: 2,
+	}
+
+	results, err := deps.svc.Foreach(context.Background(), "PROJ-8", opts, onLine)
+	if !errors.Is(err, ErrForeachFailed) {
+		t.Fatalf("expected ErrForeachFailed, got %v", err)
+	}
+
+	if len(results) != 2 || results[0].Repo != "api" || results[1].Repo != "jobs" {
+		t.Fatalf("unexpected repo selection: %+v", results)
+	}
+
+	if results[0].Err != nil || results[1].ExitCode != 1 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	joined := strings.Join(lines, "\n")
+	if !strings.Contains(joined, "api: api PROJ-8") || !strings.Contains(joined, "jobs: jobs PROJ-8") {
+		t.Fatalf("unexpected output lines:\n%s", joined)
+	}
+
+	// With fail-fast and one repo at a time, repos after the failure are skipped.
+	opts = ForeachOptions{Command: `test "$CANOPY_REPO" != api`, FailFast: true}
+
+	results, err = deps.svc.Foreach(context.Background(), "PROJ-8", opts, nil)
+	if results[0].Err == nil || !results[1].Skipped || !results[2].Skipped {
+		t.Fatalf("expected fail-fast to skip remaining repos: %+v", results)
+	}
+
+	if err == nil || !strings.Contains(err.Error(), "in 1 of 3 repos (2 skipped)") {
+		t.Fatalf("expected skipped repos to be counted apart from failures, got %v", err)
+	}
+
+	if _, err := deps.svc.Foreach(context.Background(), "PROJ-8", ForeachOptions{Selector: RepoSelector{Repos: []string{"nope"}}, Command: "true"}, nil); err == nil {
+		t.Fatalf("expected error for unknown repo")
+	}
+}
@@ -0,0 +1,116 @@
+package workspaces
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/gitx"
+)
+
+// GrepOptions configures GrepWorkspace and GrepCanonical.
+type GrepOptions struct {
+	Pattern  string
+	Selector RepoSelector
+	Git      gitx.GrepOptions
+	Parallel int // repos searched at once, at least 1
+}
+
+// GrepResult holds the matches of one repo.
+type GrepResult struct {
+	Repo    string
+	Ref     string // searched branch for canonical repos, empty for worktrees
+	Matches []gitx.GrepMatch
+	Err     error
+}
+
+type grepTarget struct {
+	repo string
+	dir  string
+	rev  string
+}
+
+// GrepWorkspace searches the tracked files of each selected worktree of a workspace.
+func (s *Service) GrepWorkspace(ctx context.Context, workspaceID string, opts GrepOptions) ([]GrepResult, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := s.selectRepos(ws, opts.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := make([]grepTarget, 0, len(repos))
+	for _, repo := range repos {
+		targets = append(targets, grepTarget{repo: repo.Name, dir: filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)})
+	}
+
+	return s.grep(ctx, targets, opts), nil
+}
+
+// GrepCanonical searches the canonical repos in the projects root at their default branch,
+// without needing a checkout.
+func (s *Service) GrepCanonical(ctx context.Context, opts GrepOptions) ([]GrepResult, error) {
+	names, err := s.gitEngine.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range opts.Selector.Repos {
+		if !slices.Contains(names, name) {
+			return nil, fmt.Errorf("canonical repository %s not found", name)
+		}
+	}
+
+	var targets []grepTarget
+
+	for _, name := range names {
+		if len(opts.Selector.Repos) > 0 && !slices.Contains(opts.Selector.Repos, name) {
+			continue
+		}
+
+		if len(opts.Selector.Tags) > 0 && !s.repoHasTag(domain.Repo{Name: name}, opts.Selector.Tags) {
+			continue
+		}
+
+		dir := filepath.Join(s.config.ProjectsRoot, name)
+		targets = append(targets, grepTarget{repo: name, dir: dir, rev: s.gitEngine.HeadBranch(dir)})
+	}
+
+	if len(targets) == 0 {
+		return nil, fmt.Errorf("no canonical repositories match the selection")
+	}
+
+	return s.grep(ctx, targets, opts), nil
+}
+
+// grep searches targets in parallel and returns results in target order.
+func (s *Service) grep(ctx context.Context, targets []grepTarget, opts GrepOptions) []GrepResult {
+	results := make([]GrepResult, len(targets))
+	sem := make(chan struct{}, max(opts.Parallel, 1))
+
+	var wg sync.WaitGroup
+
+	for i, target := range targets {
+		wg.Add(1)
+
+		go func(i int, target grepTarget) {
+			defer wg.Done()
+
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			matches, err := s.gitEngine.Grep(ctx, target.dir, opts.Pattern, target.rev, opts.Git)
+			results[i] = GrepResult{Repo: target.repo, Ref: target.rev, Matches: matches, Err: err}
+		}(i, target)
+	}
+
+	wg.Wait()
+
+	return results
+}
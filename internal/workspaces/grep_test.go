@@ -0,0 +1,73 @@
+package workspaces
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/gitx"
+)
+
+func TestGrep(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	repos := newCanonicalRepos(t, deps, "api", "web")
+
+	for _, repo := range repos {
+		source := strings.TrimPrefix(repo.URL, "file://")
+
+		if err := os.WriteFile(filepath.Join(source, "main.go"), []byte("package main\n\nfunc FetchUser() {}\n"), 0o600); err != nil {
+			t.Fatalf("failed to write file: %v", err)
+		}
+
+		runGit(t, source, "add", ".")
+		runGit(t, source, "commit", "-m", "add main")
+		runGit(t, source, "push", filepath.Join(deps.projectsRoot, repo.Name), "master")
+	}
+
+	deps.svc.registry = &config.RepoRegistry{Repos: map[string]config.RegistryEntry{
+		"api": {Alias: "api", URL: repos[0].URL, Tags: []string{"backend"}},
+		"web": {Alias: "web", URL: repos[1].URL},
+	}}
+
+	results, err := deps.svc.GrepCanonical(context.Background(), GrepOptions{
+		Pattern:  "fetchuser",
+		Selector: RepoSelector{Tags: []string{"backend"}},
+		Git:      gitx.GrepOptions{IgnoreCase: true},
+	})
+	if err != nil {
+		t.Fatalf("GrepCanonical failed: %v", err)
+	}
+
+	if len(results) != 1 || results[0].Repo != "api" || results[0].Ref != "master" {
+		t.Fatalf("unexpected canonical results: %+v", results)
+	}
+
+	want := gitx.GrepMatch{File: "main.go", Line: 3, Text: "func FetchUser() {}"}
+	if len(results[0].Matches) != 1 || results[0].Matches[0] != want {
+		t.Fatalf("unexpected matches: %+v", results[0].Matches)
+	}
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-10", Repos: repos}); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	results, err = deps.svc.GrepWorkspace(context.Background(), "PROJ-10", GrepOptions{Pattern: "Fetch(User|Order)", Parallel: 2})
+	if err != nil {
+		t.Fatalf("GrepWorkspace failed: %v", err)
+	}
+
+	if len(results) != 2 || results[1].Repo != "web" || len(results[1].Matches) != 1 || results[1].Err != nil {
+		t.Fatalf("unexpected workspace results: %+v", results)
+	}
+
+	results, _ = deps.svc.GrepWorkspace(context.Background(), "PROJ-10", GrepOptions{Pattern: "nothing-here", Git: gitx.GrepOptions{FixedStrings: true}})
+	if len(results[0].Matches) != 0 || results[0].Err != nil {
+		t.Fatalf("expected no matches without error: %+v", results)
+	}
+}
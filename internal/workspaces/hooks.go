@@ -0,0 +1,134 @@
+package workspaces
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+// Lifecycle events passed to runHooks.
+const (
+	HookPreCreate   = "pre_create"
+	HookPostCreate  = "post_create"
+	HookPreSwitch   = "pre_switch"
+	HookPostSwitch  = "post_switch"
+	HookPreArchive  = "pre_archive"
+	HookPostArchive = "post_archive"
+	HookPreRestore  = "pre_restore"
+	HookPostRestore = "post_restore"
+	HookPreClose    = "pre_close"
+	HookPostClose   = "post_close"
+)
+
+// ErrHookVeto indicates a pre-hook failed and the operation was not performed.
+var ErrHookVeto = errors.New("operation vetoed by hook")
+
+// hookRepo is the JSON shape of CANOPY_WORKSPACE_REPOS.
+type hookRepo struct {
+	Name string `json:"name"`
+	URL  string `json:"url,omitempty"`
+	Path string `json:"path"`
+}
+
+// runHooks runs the hooks configured for event. Hooks receive the workspace through
+// CANOPY_* environment variables plus any extra "KEY=value" pairs. A failing pre-hook
+// returns an error wrapping ErrHookVeto; post-hook failures are logged and ignored.
+func (s *Service) runHooks(event string, ws domain.Workspace, dirName string, extra ...string) error {
+	hooks := s.config.Hooks[event]
+	if len(hooks) == 0 {
+		return nil
+	}
+
+	root := filepath.Join(s.config.WorkspacesRoot, dirName)
+
+	repos := make([]hookRepo, 0, len(ws.Repos))
+	for _, r := range ws.Repos {
+		repos = append(repos, hookRepo{Name: r.Name, URL: r.URL, Path: filepath.Join(root, r.Name)})
+	}
+
+	reposJSON, err := json.Marshal(repos)
+	if err != nil {
+		return fmt.Errorf("failed to encode hook repos: %w", err)
+	}
+
+	env := append(os.Environ(),
+		"CANOPY_HOOK="+event,
+		"CANOPY_WORKSPACE_ID="+ws.ID,
+		"CANOPY_WORKSPACE_PATH="+root,
+		"CANOPY_WORKSPACE_BRANCH="+ws.BranchName,
+		"CANOPY_WORKSPACE_REPOS="+string(reposJSON),
+	)
+
+	for _, k := range slices.Sorted(maps.Keys(ws.Env)) {
+		env = append(env, k+"="+ws.Env[k])
+	}
+
+	env = append(env, extra...)
+
+	dir := root
+	if _, err := os.Stat(dir); err != nil {
+		dir = s.config.WorkspacesRoot
+	}
+
+	for _, hook := range hooks {
+		err := runHook(hook, dir, env)
+		if err == nil {
+			continue
+		}
+
+		if strings.HasPrefix(event, "pre_") {
+			return fmt.Errorf("%w: %s hook %q failed: %w", ErrHookVeto, event, hook.Command, err)
+		}
+
+		if s.logger != nil {
+			s.logger.Warn("Hook failed", "event", event, "workspace", ws.ID, "command", hook.Command, "error", err)
+		}
+	}
+
+	return nil
+}
+
+func runHook(hook config.HookConfig, dir string, env []string) error {
+	timeout := hook.Timeout
+	if timeout == 0 {
+		timeout = config.DefaultHookTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	var output bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", hook.Command) //nolint:gosec // hooks come from the user's configuration
+	cmd.Dir = dir
+	cmd.Env = env
+	cmd.Stdout = &output
+	cmd.Stderr = &output
+	cmd.WaitDelay = time.Second
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return fmt.Errorf("timed out after %s", timeout)
+		}
+
+		if msg := strings.TrimSpace(output.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package workspaces
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestLifecycleHooks(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	out := filepath.Join(t.TempDir(), "hook.out")
+
+	deps.svc.config.Hooks = map[string][]config.HookConfig{
+		HookPostCreate: {{Command: `printf '%s|%s|%s' "$CANOPY_HOOK" "$CANOPY_WORKSPACE_ID" "$CANOPY_WORKSPACE_REPOS" > ` + out}},
+		HookPreClose:   {{Command: `echo "database still attached" >&2; exit 1`}},
+	}
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-7"}); err != nil {
+		t.Fatalf("CreateWorkspaceFrom failed: %v", err)
+	}
+
+	data, err := os.ReadFile(out) //nolint:gosec // test reads its own output
+	if err != nil {
+		t.Fatalf("post_create hook did not run: %v", err)
+	}
+
+	parts := strings.SplitN(string(data), "|", 3)
+	if len(parts) != 3 || parts[0] != HookPostCreate || parts[1] != "PROJ-7" {
+		t.Fatalf("unexpected hook environment: %q", data)
+	}
+
+	var repos []hookRepo
+	if err := json.Unmarshal([]byte(parts[2]), &repos); err != nil {
+		t.Fatalf("CANOPY_WORKSPACE_REPOS is not JSON: %v", err)
+	}
+
+	err = deps.svc.CloseWorkspace("PROJ-7", true)
+	if !errors.Is(err, ErrHookVeto) || !strings.Contains(err.Error(), "database still attached") {
+		t.Fatalf("expected pre_close veto, got %v", err)
+	}
+
+	if _, _, err := deps.svc.findWorkspace("PROJ-7"); err != nil {
+		t.Fatalf("vetoed close should keep the workspace: %v", err)
+	}
+
+	deps.svc.config.Hooks[HookPreClose] = []config.HookConfig{{Command: "sleep 5", Timeout: 50 * time.Millisecond}}
+
+	err = deps.svc.CloseWorkspace("PROJ-7", true)
+	if !errors.Is(err, ErrHookVeto) || !strings.Contains(err.Error(), "timed out") {
+		t.Fatalf("expected pre_close timeout veto, got %v", err)
+	}
+
+	delete(deps.svc.config.Hooks, HookPreClose)
+
+	if err := deps.svc.CloseWorkspace("PROJ-7", true); err != nil {
+		t.Fatalf("CloseWorkspace failed: %v", err)
+	}
+}
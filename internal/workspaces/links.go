@@ -0,0 +1,114 @@
+package workspaces
+
+import (
+	"errors"
+	"path/filepath"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/gowork"
+	"github.com/alexisbeaulieu97/canopy/internal/linker"
+)
+
+// SyncGoWork regenerates the workspace go.work from the go.mod files in its repos and returns
+// the module directories it lists. force takes over a go.work that canopy did not generate.
+// It fails when go.work generation is turned off with go_work: false.
+func (s *Service) SyncGoWork(workspaceID string, force bool) ([]string, error) {
+	if !s.config.GoWork {
+		return nil, errors.New("go.work generation is disabled (go_work: false in the config)")
+	}
+
+	_, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return gowork.Sync(filepath.Join(s.config.WorkspacesRoot, dirName), force)
+}
+
+// LinkWorkspace runs every enabled linker for a workspace and returns what each one linked.
+// Linkers that find no matching repos are omitted from the result.
+func (s *Service) LinkWorkspace(workspaceID string) ([]linker.Result, error) {
+	targetWorkspace, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	linkers, err := s.enabledLinkers()
+	if err != nil {
+		return nil, err
+	}
+
+	root := filepath.Join(s.config.WorkspacesRoot, dirName)
+
+	var results []linker.Result
+
+	for _, l := range linkers {
+		result, err := l.Sync(root, repoNames(targetWorkspace.Repos))
+		if err != nil {
+			return results, err
+		}
+
+		if len(result.Repos) > 0 {
+			results = append(results, result)
+		}
+	}
+
+	return results, nil
+}
+
+// enabledLinkers returns the configured linkers; go is controlled by the go_work setting.
+func (s *Service) enabledLinkers() ([]linker.Linker, error) {
+	var names []string
+
+	if s.config.GoWork {
+		names = append(names, "go")
+	}
+
+	for _, name := range s.config.Linkers {
+		if name != "go" {
+			names = append(names, name)
+		}
+	}
+
+	return linker.ByName(names)
+}
+
+// refreshLinks keeps link files in step after repos change. Failures are logged rather than
+// failing the repo operation that triggered them.
+func (s *Service) refreshLinks(dirName string, ws domain.Workspace) {
+	linkers, err := s.enabledLinkers()
+	if err != nil {
+		s.logLinkError(dirName, err)
+		return
+	}
+
+	root := filepath.Join(s.config.WorkspacesRoot, dirName)
+
+	for _, l := range linkers {
+		if _, err := l.Sync(root, repoNames(ws.Repos)); err != nil {
+			s.logLinkError(dirName, err)
+		}
+	}
+}
+
+func (s *Service) logLinkError(dirName string, err error) {
+	if s.logger == nil {
+		return
+	}
+
+	if errors.Is(err, linker.ErrUserManaged) {
+		s.logger.Debug("Leaving user-managed link file untouched", "workspace", dirName, "error", err)
+		return
+	}
+
+	s.logger.Warn("Failed to update workspace links", "workspace", dirName, "error", err)
+}
+
+func repoNames(repos []domain.Repo) []string {
+	names := make([]string, 0, len(repos))
+	for _, r := range repos {
+		names = append(names, r.Name)
+	}
+
+	return names
+}
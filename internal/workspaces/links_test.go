@@ -0,0 +1,64 @@
+package workspaces
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestGoWorkFollowsWorkspaceRepos(t *testing.T) {
+	deps := newTestService(t)
+	deps.svc.config.GoWork = true
+
+	repos := newCanonicalRepos(t, deps, "svc", "lib")
+
+	for _, repo := range repos {
+		source := strings.TrimPrefix(repo.URL, "file://")
+
+		if err := os.WriteFile(filepath.Join(source, "go.mod"), []byte("module example.com/"+repo.Name+"\n\ngo 1.22\n"), 0o644); err != nil {
+			t.Fatalf("failed to write go.mod: %v", err)
+		}
+
+		runGit(t, source, "add", "go.mod")
+		runGit(t, source, "commit", "-m", "add module")
+		runGit(t, source, "push", filepath.Join(deps.projectsRoot, repo.Name), "master")
+	}
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-8", Repos: repos}); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	goWork := filepath.Join(deps.workspacesRoot, "PROJ-8", "go.work")
+
+	content, err := os.ReadFile(goWork)
+	if err != nil {
+		t.Fatalf("go.work not generated: %v", err)
+	}
+
+	if !strings.Contains(string(content), "./lib") || !strings.Contains(string(content), "./svc") {
+		t.Fatalf("go.work missing modules:\n%s", content)
+	}
+
+	results, err := deps.svc.LinkWorkspace("PROJ-8")
+	if err != nil || len(results) != 1 || results[0].Linker != "go" || len(results[0].Repos) != 2 {
+		t.Fatalf("unexpected link results: %+v (%v)", results, err)
+	}
+
+	if err := deps.svc.RemoveRepoFromWorkspace("PROJ-8", "lib"); err != nil {
+		t.Fatalf("RemoveRepoFromWorkspace failed: %v", err)
+	}
+
+	content, _ = os.ReadFile(goWork)
+	if strings.Contains(string(content), "./lib") || !strings.Contains(string(content), "./svc") {
+		t.Fatalf("go.work not updated after removal:\n%s", content)
+	}
+
+	deps.svc.config.GoWork = false
+
+	if _, err := deps.svc.SyncGoWork("PROJ-8", false); err == nil {
+		t.Fatalf("expected SyncGoWork to respect go_work: false")
+	}
+}
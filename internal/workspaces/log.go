@@ -0,0 +1,59 @@
+package workspaces
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/gitx"
+)
+
+// LogEntry is a commit of a workspace repo.
+type LogEntry struct {
+	Repo string
+	gitx.Commit
+}
+
+// WorkspaceLog lists the commits made on the workspace branch since its base in every repo,
+// newest first across repos.
+func (s *Service) WorkspaceLog(workspaceID string) ([]LogEntry, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []LogEntry
+
+	for _, repo := range ws.Repos {
+		path := filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)
+
+		commits, err := s.gitEngine.Log(path, s.repoBase(path, repo), "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read log of %s: %w", repo.Name, err)
+		}
+
+		for _, c := range commits {
+			entries = append(entries, LogEntry{Repo: repo.Name, Commit: c})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].Date.After(entries[j].Date) })
+
+	return entries, nil
+}
+
+// repoBase returns the ref a repo's workspace branch started from: its base ref, preferring
+// the remote-tracking branch, or the remote default branch.
+func (s *Service) repoBase(path string, repo domain.Repo) string {
+	base := repo.BaseRef
+	if base == "" {
+		base = s.gitEngine.DefaultBranch(path)
+	}
+
+	if s.gitEngine.HasRemoteBranch(path, "origin", base) {
+		return "origin/" + base
+	}
+
+	return base
+}
@@ -0,0 +1,46 @@
+package workspaces
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestWorkspaceLog(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	newTestWorkspace(t, deps, domain.Workspace{ID: "PROJ-13"}, "api", "web")
+
+	commit := func(repo, subject, date string) {
+		worktree := filepath.Join(deps.workspacesRoot, "PROJ-13", repo)
+		runGit(t, worktree, "-c", "user.email=test@example.com", "-c", "user.name=Test",
+			"commit", "--allow-empty", "-m", subject, "--date", date)
+	}
+
+	commit("api", "feat: add users", "2026-01-01T10:00:00Z")
+	commit("web", "fix: render users", "2026-01-01T11:00:00Z")
+	commit("api", "test: cover users", "2026-01-01T12:00:00Z")
+
+	entries, err := deps.svc.WorkspaceLog("PROJ-13")
+	if err != nil {
+		t.Fatalf("WorkspaceLog failed: %v", err)
+	}
+
+	want := []struct{ repo, subject string }{
+		{"api", "test: cover users"},
+		{"web", "fix: render users"},
+		{"api", "feat: add users"},
+	}
+
+	if len(entries) != len(want) {
+		t.Fatalf("expected %d entries, got %+v", len(want), entries)
+	}
+
+	for i, w := range want {
+		if entries[i].Repo != w.repo || entries[i].Subject != w.subject {
+			t.Fatalf("entry %d = %s %q, want %s %q", i, entries[i].Repo, entries[i].Subject, w.repo, w.subject)
+		}
+	}
+}
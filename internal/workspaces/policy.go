@@ -0,0 +1,112 @@
+package workspaces
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+// ErrPolicyViolation indicates a branch breaks the configured branch policy.
+var ErrPolicyViolation = errors.New("branch policy violated")
+
+// PolicyError lists every branch policy violation found for an operation.
+type PolicyError struct {
+	Violations []string
+}
+
+func (e *PolicyError) Error() string {
+	return fmt.Sprintf("%s:\n  - %s\nuse --override-policy to proceed anyway", ErrPolicyViolation, strings.Join(e.Violations, "\n  - "))
+}
+
+func (e *PolicyError) Unwrap() error {
+	return ErrPolicyViolation
+}
+
+// checkBranchPolicy validates branches against the configured policy: protected branch
+// patterns, the naming rule of the workspace and the maximum length. Violations are only
+// logged when override is set; an invalid naming rule is a configuration error and is
+// always returned.
+func (s *Service) checkBranchPolicy(workspaceID string, override bool, branches ...string) error {
+	var violations []string
+
+	seen := make(map[string]bool, len(branches))
+
+	for _, branch := range branches {
+		if branch == "" || seen[branch] {
+			continue
+		}
+
+		seen[branch] = true
+
+		found, err := s.branchViolations(workspaceID, branch)
+		if err != nil {
+			return err
+		}
+
+		violations = append(violations, found...)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+
+	if override {
+		if s.logger != nil {
+			s.logger.Warn("Overriding branch policy", "workspace", workspaceID, "violations", strings.Join(violations, "; "))
+		}
+
+		return nil
+	}
+
+	return &PolicyError{Violations: violations}
+}
+
+func (s *Service) branchViolations(workspaceID, branch string) ([]string, error) {
+	policy := s.config.Policy
+
+	var violations []string
+
+	for _, pattern := range policy.ProtectedBranches {
+		if matched, _ := path.Match(pattern, branch); matched {
+			violations = append(violations, fmt.Sprintf("branch %q is protected (matches %q)", branch, pattern))
+			break
+		}
+	}
+
+	if rule, ok := s.config.BranchNameRuleFor(workspaceID); ok {
+		re, err := rule.BranchRegexp(workspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("policy.branch_names: invalid branch pattern %q: %w", rule.Branch, err)
+		}
+
+		if !re.MatchString(branch) {
+			violations = append(violations, fmt.Sprintf("branch %q does not match %s, required for workspace %s", branch, re, workspaceID))
+		}
+	}
+
+	if policy.MaxBranchLength > 0 && len(branch) > policy.MaxBranchLength {
+		violations = append(violations, fmt.Sprintf("branch %q is %d characters long, the maximum is %d", branch, len(branch), policy.MaxBranchLength))
+	}
+
+	return violations, nil
+}
+
+// workspaceBranches returns the branches a workspace checks out; detached repos have none.
+func workspaceBranches(ws domain.Workspace) []string {
+	var branches []string
+
+	for _, repo := range ws.Repos {
+		if repo.Ref == "" {
+			branches = append(branches, repoBranch(repo, ws.BranchName))
+		}
+	}
+
+	if len(ws.Repos) == 0 {
+		branches = append(branches, ws.BranchName)
+	}
+
+	return branches
+}
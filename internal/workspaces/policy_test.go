@@ -0,0 +1,84 @@
+package workspaces
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestBranchPolicy(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	deps.svc.config.Policy = config.PolicyConfig{
+		ProtectedBranches: []string{"main", "release/*"},
+		BranchNames:       []config.BranchNameRule{{Workspace: "^PROJ-", Branch: "^feature/{{.ID}}-"}},
+		MaxBranchLength:   40,
+	}
+
+	repos := newCanonicalRepos(t, deps, "api")
+
+	_, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-19", Repos: repos})
+
+	var policyErr *PolicyError
+	if !errors.As(err, &policyErr) || !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("expected a policy error for the default branch name, got %v", err)
+	}
+
+	if len(policyErr.Violations) != 1 || !strings.Contains(policyErr.Violations[0], `^feature/PROJ-19-`) {
+		t.Fatalf("unexpected violations: %v", policyErr.Violations)
+	}
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-19", BranchName: "feature/PROJ-19-login", Repos: repos}); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	err = deps.svc.SwitchBranch("PROJ-19", "release/1.0", true, false)
+	if !errors.As(err, &policyErr) || len(policyErr.Violations) != 2 {
+		t.Fatalf("expected protected and naming violations, got %v", err)
+	}
+
+	err = deps.svc.SwitchBranch("PROJ-19", "feature/PROJ-19-a-branch-name-that-is-far-too-long", true, false)
+	if !errors.As(err, &policyErr) || !strings.Contains(policyErr.Violations[0], "maximum is 40") {
+		t.Fatalf("expected a length violation, got %v", err)
+	}
+
+	if err := deps.svc.SwitchBranch("PROJ-19", "main", true, true); err != nil {
+		t.Fatalf("SwitchBranch with override failed: %v", err)
+	}
+
+	// A protected branch with nothing to push is skipped, not refused.
+	results, err := deps.svc.PushWorkspace("PROJ-19", PushOptions{DryRun: true})
+	if err != nil || results[0].Skipped == "" {
+		t.Fatalf("expected main without new commits to be skipped, got %+v (%v)", results, err)
+	}
+
+	worktree := filepath.Join(deps.workspacesRoot, "PROJ-19", "api")
+	runGit(t, worktree, "-c", "user.email=test@example.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", "wip")
+
+	if _, err := deps.svc.PushWorkspace("PROJ-19", PushOptions{DryRun: true}); !errors.Is(err, ErrPolicyViolation) {
+		t.Fatalf("expected pushing main to be refused, got %v", err)
+	}
+
+	if _, err := deps.svc.PushWorkspace("PROJ-19", PushOptions{DryRun: true, OverridePolicy: true}); err != nil {
+		t.Fatalf("push with override failed: %v", err)
+	}
+}
+
+func TestBranchPolicyInvalidRuleIsNotOverridable(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	deps.svc.config.Policy = config.PolicyConfig{
+		BranchNames: []config.BranchNameRule{{Branch: "^feature/({{.ID}}"}},
+	}
+
+	_, err := deps.svc.CreateWorkspaceWithOptions(domain.Workspace{ID: "PROJ-20"}, CreateOptions{OverridePolicy: true})
+	if err == nil || errors.Is(err, ErrPolicyViolation) || !strings.Contains(err.Error(), "invalid branch pattern") {
+		t.Fatalf("expected a configuration error, got %v", err)
+	}
+}
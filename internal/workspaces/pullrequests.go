@@ -0,0 +1,248 @@
+package workspaces
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/forge"
+)
+
+// relatedMarker delimits the sibling pull request section canopy maintains in PR descriptions.
+const relatedMarker = "<!-- canopy:related -->"
+
+// PullRequestOptions controls how workspace pull requests are opened.
+type PullRequestOptions struct {
+	Title string
+	Body  string
+	Base  string
+	Draft bool
+}
+
+type repoPullRequest struct {
+	repo  domain.Repo
+	forge forge.Forge
+	ref   forge.RepoRef
+	pr    *forge.PullRequest
+	body  string
+}
+
+// OpenPullRequests opens or updates one pull request per pushed repo with a shared title and body,
+// cross-links the sibling pull requests and records their URLs in workspace metadata.
+func (s *Service) OpenPullRequests(ctx context.Context, workspaceID string, opts PullRequestOptions) ([]domain.PullRequest, error) { //nolint:gocyclo // orchestrates create/update and cross-linking
+	targetWorkspace, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	title := opts.Title
+	if title == "" {
+		title = targetWorkspace.ID
+		if targetWorkspace.Description != "" {
+			title += ": " + targetWorkspace.Description
+		}
+	}
+
+	var opened []repoPullRequest
+
+	for _, repo := range targetWorkspace.Repos {
+		worktreePath := fmt.Sprintf("%s/%s/%s", s.config.WorkspacesRoot, dirName, repo.Name)
+		branchName := targetWorkspace.BranchName
+
+		if !s.gitEngine.HasRemoteBranch(worktreePath, "origin", branchName) {
+			if s.logger != nil {
+				s.logger.Info("Skipping repo without pushed branch", "repo", repo.Name, "branch", branchName)
+			}
+
+			continue
+		}
+
+		f, ref, err := s.forgeFor(repo.URL)
+		if err != nil {
+			return nil, fmt.Errorf("repo %s: %w", repo.Name, err)
+		}
+
+		req := forge.PullRequestRequest{
+			Title: title,
+			Body:  opts.Body,
+			Head:  branchName,
+			Base:  s.baseBranch(repo, worktreePath, opts.Base),
+			Draft: opts.Draft,
+		}
+
+		existing, err := f.FindPullRequest(ctx, ref, branchName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up pull request for %s: %w", repo.Name, err)
+		}
+
+		var pr *forge.PullRequest
+
+		if existing != nil {
+			if req.Body == "" {
+				req.Body = stripRelatedSection(existing.Body)
+			}
+
+			pr, err = f.UpdatePullRequest(ctx, ref, existing.Number, req)
+		} else {
+			pr, err = f.CreatePullRequest(ctx, ref, req)
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to open pull request for %s: %w", repo.Name, err)
+		}
+
+		opened = append(opened, repoPullRequest{repo: repo, forge: f, ref: ref, pr: pr, body: req.Body})
+	}
+
+	if len(opened) == 0 {
+		return nil, fmt.Errorf("no pushed repositories in workspace %s; push the workspace first", workspaceID)
+	}
+
+	// Link every pull request to its siblings once all URLs are known.
+	if len(opened) > 1 {
+		for _, item := range opened {
+			req := forge.PullRequestRequest{Title: title, Body: withRelatedSection(item.body, item.repo.Name, opened), Draft: opts.Draft}
+			if _, err := item.forge.UpdatePullRequest(ctx, item.ref, item.pr.Number, req); err != nil {
+				return nil, fmt.Errorf("failed to link pull request for %s: %w", item.repo.Name, err)
+			}
+		}
+	}
+
+	var result []domain.PullRequest
+
+	for _, item := range opened {
+		record := domain.PullRequest{Repo: item.repo.Name, Number: item.pr.Number, URL: item.pr.URL}
+		targetWorkspace.PullRequests = upsertPullRequest(targetWorkspace.PullRequests, record)
+		result = append(result, record)
+	}
+
+	if err := s.wsEngine.Save(dirName, *targetWorkspace); err != nil {
+		return nil, fmt.Errorf("failed to update workspace metadata: %w", err)
+	}
+
+	return result, nil
+}
+
+// PullRequestStatuses returns the live forge state of every pull request recorded for a workspace.
+func (s *Service) PullRequestStatuses(ctx context.Context, workspaceID string) ([]domain.PullRequestStatus, error) {
+	targetWorkspace, _, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var statuses []domain.PullRequestStatus
+
+	for _, pr := range targetWorkspace.PullRequests {
+		status := domain.PullRequestStatus{PullRequest: pr}
+
+		live, err := s.fetchPullRequest(ctx, targetWorkspace, pr)
+		if err != nil {
+			status.Error = err.Error()
+		} else {
+			status.Title = live.Title
+			status.State = live.State
+			status.ReviewState = live.ReviewState
+			status.Draft = live.Draft
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
+func (s *Service) fetchPullRequest(ctx context.Context, ws *domain.Workspace, pr domain.PullRequest) (*forge.PullRequest, error) {
+	for _, repo := range ws.Repos {
+		if repo.Name != pr.Repo {
+			continue
+		}
+
+		f, ref, err := s.forgeFor(repo.URL)
+		if err != nil {
+			return nil, err
+		}
+
+		return f.GetPullRequest(ctx, ref, pr.Number)
+	}
+
+	return nil, fmt.Errorf("repository %s is no longer part of the workspace", pr.Repo)
+}
+
+// forgeFor resolves the configured forge hosting a repository URL.
+func (s *Service) forgeFor(repoURL string) (forge.Forge, forge.RepoRef, error) {
+	ref, err := forge.ParseRepoURL(repoURL)
+	if err != nil {
+		return nil, forge.RepoRef{}, err
+	}
+
+	cfg, ok := s.config.ForgeFor(ref.Host)
+	if !ok {
+		return nil, forge.RepoRef{}, fmt.Errorf("no forge configured for host %s", ref.Host)
+	}
+
+	f, err := forge.New(cfg)
+	if err != nil {
+		return nil, forge.RepoRef{}, err
+	}
+
+	return f, ref, nil
+}
+
+// baseBranch picks the branch a workspace repo is compared against: explicit override,
+// registry default branch, then the canonical repository's HEAD.
+func (s *Service) baseBranch(repo domain.Repo, worktreePath, override string) string {
+	if override != "" {
+		return override
+	}
+
+	if s.registry != nil {
+		if entry, ok := s.registry.Resolve(repo.Name); ok && entry.DefaultBranch != "" {
+			return entry.DefaultBranch
+		}
+	}
+
+	return s.gitEngine.DefaultBranch(worktreePath)
+}
+
+func withRelatedSection(body, self string, siblings []repoPullRequest) string {
+	var b strings.Builder
+
+	b.WriteString(stripRelatedSection(body))
+
+	if b.Len() > 0 {
+		b.WriteString("\n\n")
+	}
+
+	b.WriteString(relatedMarker)
+	b.WriteString("\n**Related pull requests**\n")
+
+	for _, sibling := range siblings {
+		if sibling.repo.Name == self {
+			continue
+		}
+
+		fmt.Fprintf(&b, "- %s: %s\n", sibling.repo.Name, sibling.pr.URL)
+	}
+
+	return b.String()
+}
+
+func stripRelatedSection(body string) string {
+	if idx := strings.Index(body, relatedMarker); idx >= 0 {
+		body = body[:idx]
+	}
+
+	return strings.TrimRight(body, "\n ")
+}
+
+func upsertPullRequest(prs []domain.PullRequest, record domain.PullRequest) []domain.PullRequest {
+	for i, pr := range prs {
+		if pr.Repo == record.Repo {
+			prs[i] = record
+			return prs
+		}
+	}
+
+	return append(prs, record)
+}
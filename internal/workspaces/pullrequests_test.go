@@ -0,0 +1,151 @@
+package workspaces
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+// fakeGitHub is a minimal in-memory GitHub pulls API.
+type fakeGitHub struct {
+	mu    sync.Mutex
+	pulls map[string]map[string]any // keyed by "repo#number"
+	next  int
+}
+
+func (f *fakeGitHub) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/acme/{repo}/pulls", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	})
+	mux.HandleFunc("POST /repos/acme/{repo}/pulls", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		f.next++
+		payload["number"] = f.next
+		payload["head"] = map[string]any{"ref": payload["head"]}
+		payload["html_url"] = fmt.Sprintf("https://github.com/acme/%s/pull/%d", r.PathValue("repo"), f.next)
+		payload["state"] = "open"
+		f.pulls[fmt.Sprintf("%s#%d", r.PathValue("repo"), f.next)] = payload
+
+		_ = json.NewEncoder(w).Encode(payload)
+	})
+	mux.HandleFunc("PATCH /repos/acme/{repo}/pulls/{number}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+
+		pull := f.pulls[r.PathValue("repo")+"#"+r.PathValue("number")]
+
+		var payload map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+
+		for k, v := range payload {
+			pull[k] = v
+		}
+
+		_ = json.NewEncoder(w).Encode(pull)
+	})
+
+	return mux
+}
+
+func TestOpenPullRequestsLinksSiblings(t *testing.T) {
+	deps := newTestService(t)
+
+	fake := &fakeGitHub{pulls: make(map[string]map[string]any)}
+	server := httptest.NewServer(fake.handler())
+	t.Cleanup(server.Close)
+
+	deps.svc.config.Forges = []config.ForgeConfig{{Type: "github", Host: "github.com", APIURL: server.URL}}
+
+	repos := newCanonicalRepos(t, deps, "api", "web", "docs")
+	for i := range repos {
+		repos[i].URL = "https://github.com/acme/" + repos[i].Name + ".git"
+	}
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-9", Description: "Rename field", Repos: repos}); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	// Push only api and web; docs has no remote branch and should be skipped.
+	for _, name := range []string{"api", "web"} {
+		runGit(t, filepath.Join(deps.workspacesRoot, "PROJ-9", name), "push", "origin", "PROJ-9")
+	}
+
+	prs, err := deps.svc.OpenPullRequests(t.Context(), "PROJ-9", PullRequestOptions{Body: "Shared body"})
+	if err != nil {
+		t.Fatalf("OpenPullRequests failed: %v", err)
+	}
+
+	if len(prs) != 2 {
+		t.Fatalf("expected 2 pull requests, got %+v", prs)
+	}
+
+	apiPull := fake.pulls["api#1"]
+	if apiPull["title"] != "PROJ-9: Rename field" {
+		t.Fatalf("unexpected title: %v", apiPull["title"])
+	}
+
+	body, _ := apiPull["body"].(string)
+	if !strings.HasPrefix(body, "Shared body") || !strings.Contains(body, "https://github.com/acme/web/pull/2") || strings.Contains(body, "acme/api/pull") {
+		t.Fatalf("api body should link only its sibling: %q", body)
+	}
+
+	ws, err := deps.svc.GetWorkspace("PROJ-9")
+	if err != nil {
+		t.Fatalf("GetWorkspace failed: %v", err)
+	}
+
+	if len(ws.PullRequests) != 2 || ws.PullRequests[1].URL != "https://github.com/acme/web/pull/2" {
+		t.Fatalf("pull requests not recorded: %+v", ws.PullRequests)
+	}
+}
+
+func TestOpenPullRequestsKeepsDraftsWhenLinking(t *testing.T) {
+	deps := newTestService(t)
+
+	// Gitea's pulls API has the same shape and marks drafts by title.
+	fake := &fakeGitHub{pulls: make(map[string]map[string]any)}
+	server := httptest.NewServer(fake.handler())
+	t.Cleanup(server.Close)
+
+	deps.svc.config.Forges = []config.ForgeConfig{{Type: "gitea", Host: "gitea.example.com", APIURL: server.URL}}
+
+	repos := newCanonicalRepos(t, deps, "api", "web")
+	for i := range repos {
+		repos[i].URL = "https://gitea.example.com/acme/" + repos[i].Name + ".git"
+	}
+
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-29", Description: "Rename field", Repos: repos}); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	for _, repo := range repos {
+		runGit(t, filepath.Join(deps.workspacesRoot, "PROJ-29", repo.Name), "push", "origin", "PROJ-29")
+	}
+
+	if _, err := deps.svc.OpenPullRequests(t.Context(), "PROJ-29", PullRequestOptions{Draft: true}); err != nil {
+		t.Fatalf("OpenPullRequests failed: %v", err)
+	}
+
+	for _, key := range []string{"api#1", "web#2"} {
+		pull := fake.pulls[key]
+		body, _ := pull["body"].(string)
+
+		if pull["title"] != "WIP: PROJ-29: Rename field" || !strings.Contains(body, "/pull/") {
+			t.Fatalf("%s should stay a linked draft, got title %v and body %q", key, pull["title"], body)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package workspaces
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/gitx"
+)
+
+// PushOptions configures PushWorkspace.
+type PushOptions struct {
+	Selector       RepoSelector
+	Remote         string // defaults to origin
+	ForceWithLease bool   // for pushing rebased branches
+	DryRun         bool
+	SkipScan       bool // push without scanning outgoing commits for secrets
+	OverridePolicy bool // log branch policy violations instead of refusing to push
+}
+
+// PushResult is the outcome of pushing one repo.
+type PushResult struct {
+	Repo    string
+	Branch  string
+	Commits int    // commits on the branch beyond its base
+	Skipped string // reason the repo was not pushed, if any
+	Err     error
+}
+
+// ErrPushFailed indicates the push failed in at least one repo.
+var ErrPushFailed = errors.New("push failed")
+
+// PushWorkspace pushes the branch of every selected repo and sets its upstream. Read-only
+// repos, detached checkouts, repos without commits beyond their base and branches already
+// up to date on the remote are skipped, so no empty branches are created. Nothing is pushed
+// when a branch breaks the branch policy or outgoing commits contain suspected secrets
+// (a *SecretsError). Otherwise all repos are attempted; the error wraps ErrPushFailed when
+// any of them failed.
+func (s *Service) PushWorkspace(workspaceID string, opts PushOptions) ([]PushResult, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := s.selectRepos(ws, opts.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := opts.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+
+	results := make([]PushResult, len(repos))
+
+	var pushing []string
+
+	for i, repo := range repos {
+		path := filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)
+		branch := s.gitEngine.HeadBranch(path)
+		results[i] = PushResult{Repo: repo.Name, Branch: branch}
+
+		switch {
+		case repo.ReadOnly:
+			results[i].Skipped = "read-only"
+		case branch == "HEAD" || branch == "":
+			results[i].Skipped = "detached HEAD"
+		default:
+			results[i].Skipped, results[i].Commits, results[i].Err = s.pushSkipReason(path, repo, remote, branch)
+		}
+
+		if results[i].Skipped == "" && results[i].Err == nil {
+			pushing = append(pushing, branch)
+		}
+	}
+
+	// Only branches that are actually pushed are held to the policy.
+	if err := s.checkBranchPolicy(ws.ID, opts.OverridePolicy, pushing...); err != nil {
+		return nil, err
+	}
+
+	if !opts.SkipScan {
+		if err := s.scanBeforePush(dirName, repos, results, remote); err != nil {
+			return nil, err
+		}
+	}
+
+	var failed []string
+
+	for i, repo := range repos {
+		result := &results[i]
+
+		if result.Skipped == "" && result.Err == nil {
+			result.Err = s.gitEngine.Push(filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name), result.Branch, gitx.PushOptions{
+				Remote:         remote,
+				ForceWithLease: opts.ForceWithLease,
+				DryRun:         opts.DryRun,
+			})
+		}
+
+		if result.Err != nil {
+			failed = append(failed, repo.Name)
+		} else if result.Skipped != "" && s.logger != nil {
+			s.logger.Info("Skipping push", "workspace", workspaceID, "repo", repo.Name, "reason", result.Skipped)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%w in %s", ErrPushFailed, strings.Join(failed, ", "))
+	}
+
+	return results, nil
+}
+
+// scanBeforePush scans the outgoing commits of the repos about to be pushed and returns a
+// *SecretsError when any of them contains suspected secrets.
+func (s *Service) scanBeforePush(dirName string, repos []domain.Repo, results []PushResult, remote string) error {
+	rules, err := s.secretRules()
+	if err != nil {
+		return err
+	}
+
+	var blocked []ScanResult
+
+	for i, repo := range repos {
+		if results[i].Skipped != "" || results[i].Err != nil {
+			continue
+		}
+
+		path := filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)
+
+		scan := s.scanRepo(path, repo, remote, results[i].Branch, rules)
+		if scan.Err != nil {
+			return fmt.Errorf("failed to scan %s for secrets: %w", repo.Name, scan.Err)
+		}
+
+		if len(scan.Findings) > 0 {
+			blocked = append(blocked, scan)
+		}
+	}
+
+	if len(blocked) > 0 {
+		return &SecretsError{Results: blocked}
+	}
+
+	return nil
+}
+
+// pushSkipReason returns why a branch does not need pushing, or "" when it does, along with
+// the number of commits it has beyond its base.
+func (s *Service) pushSkipReason(path string, repo domain.Repo, remote, branch string) (string, int, error) {
+	base := s.repoBase(path, repo)
+
+	commits, err := s.gitEngine.CountCommits(path, base, "HEAD")
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to compare with %s: %w", base, err)
+	}
+
+	if commits == 0 {
+		return "no commits beyond " + base, 0, nil
+	}
+
+	if s.gitEngine.HasRemoteBranch(path, remote, branch) {
+		head, headErr := s.gitEngine.RevParse(path, "HEAD")
+		pushed, pushedErr := s.gitEngine.RevParse(path, "refs/remotes/"+remote+"/"+branch)
+
+		if headErr == nil && pushedErr == nil && head == pushed {
+			return "up to date", commits, nil
+		}
+	}
+
+	return "", commits, nil
+}
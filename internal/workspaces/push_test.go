@@ -0,0 +1,73 @@
+package workspaces
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestPushWorkspaceSkipsReposWithoutCommits(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	newTestWorkspace(t, deps, domain.Workspace{ID: "PROJ-18", BranchName: "feature"}, "api", "web")
+
+	api := filepath.Join(deps.workspacesRoot, "PROJ-18", "api")
+	canonical := filepath.Join(deps.projectsRoot, "api")
+
+	runGit(t, api, "config", "user.email", "test@example.com")
+	runGit(t, api, "config", "user.name", "Test User")
+
+	if err := os.WriteFile(filepath.Join(api, "README.md"), []byte("feature"), 0o600); err != nil {
+		t.Fatalf("failed to modify api: %v", err)
+	}
+
+	runGit(t, api, "commit", "-am", "feature")
+
+	results, err := deps.svc.PushWorkspace("PROJ-18", PushOptions{DryRun: true})
+	if err != nil {
+		t.Fatalf("dry-run push failed: %v", err)
+	}
+
+	if len(results) != 2 || results[0].Skipped != "" || results[0].Commits != 1 || results[1].Skipped == "" {
+		t.Fatalf("unexpected dry-run results: %+v", results)
+	}
+
+	if out := runGitOutput(t, canonical, "branch", "--list", "feature"); out != "" {
+		t.Fatalf("dry run must not create the remote branch, got %q", out)
+	}
+
+	if _, err := deps.svc.PushWorkspace("PROJ-18", PushOptions{}); err != nil {
+		t.Fatalf("PushWorkspace failed: %v", err)
+	}
+
+	if out := runGitOutput(t, canonical, "branch", "--list", "feature"); out == "" {
+		t.Fatalf("expected api feature branch to be pushed")
+	}
+
+	if out := runGitOutput(t, filepath.Join(deps.projectsRoot, "web"), "branch", "--list", "feature"); out != "" {
+		t.Fatalf("web has no commits and must not get an empty remote branch, got %q", out)
+	}
+
+	results, err = deps.svc.PushWorkspace("PROJ-18", PushOptions{})
+	if err != nil || results[0].Skipped != "up to date" {
+		t.Fatalf("expected api to be up to date, got %+v (%v)", results, err)
+	}
+
+	// Rewrite the pushed commit: a plain push is rejected, --force-with-lease goes through.
+	runGit(t, api, "commit", "--amend", "-m", "feature, reworded")
+
+	if _, err := deps.svc.PushWorkspace("PROJ-18", PushOptions{}); err == nil {
+		t.Fatalf("expected a non-fast-forward push to fail")
+	}
+
+	if _, err := deps.svc.PushWorkspace("PROJ-18", PushOptions{ForceWithLease: true}); err != nil {
+		t.Fatalf("force-with-lease push failed: %v", err)
+	}
+
+	if got, want := runGitOutput(t, canonical, "rev-parse", "feature"), runGitOutput(t, api, "rev-parse", "HEAD"); got != want {
+		t.Fatalf("remote feature = %s, want %s", got, want)
+	}
+}
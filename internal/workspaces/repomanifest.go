@@ -0,0 +1,102 @@
+package workspaces
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/repomanifest"
+)
+
+// ImportRepoManifest registers the projects of a `repo` XML manifest and creates a workspace
+// from them. Each repo's branch starts from the project revision. Relative fetch URLs are
+// resolved against manifestURL, which defaults to the origin of the git checkout holding the
+// manifest (as `repo init` leaves it). It returns the workspace directory name and the
+// aliases newly added to the registry.
+func (s *Service) ImportRepoManifest(file, manifestURL, workspaceID, branchName string) (string, []string, error) {
+	m, err := repomanifest.Load(file)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if manifestURL == "" {
+		manifestURL, _ = s.gitEngine.RemoteURL(filepath.Dir(file), "origin")
+	}
+
+	projects, err := m.Resolve(manifestURL)
+	if err != nil {
+		return "", nil, err
+	}
+
+	if len(projects) == 0 {
+		return "", nil, fmt.Errorf("manifest %s lists no projects", file)
+	}
+
+	var (
+		repos      []domain.Repo
+		registered []string
+	)
+
+	for _, p := range projects {
+		alias := p.Name
+
+		if s.registry != nil {
+			if entry, ok := s.registry.ResolveByURL(p.URL); ok {
+				alias = entry.Alias
+			} else {
+				entry := config.RegistryEntry{URL: p.URL}
+				if !repomanifest.IsCommitSHA(p.Revision) {
+					entry.DefaultBranch = p.Revision
+				}
+
+				alias, err = s.registry.RegisterWithSuffix(p.Name, entry)
+				if err != nil {
+					return "", nil, fmt.Errorf("failed to register %s: %w", p.URL, err)
+				}
+
+				registered = append(registered, alias)
+			}
+		}
+
+		repos = append(repos, domain.Repo{Name: alias, URL: p.URL, BaseRef: p.Revision})
+	}
+
+	if len(registered) > 0 {
+		if err := s.registry.Save(); err != nil {
+			return "", nil, fmt.Errorf("failed to save registry: %w", err)
+		}
+	}
+
+	dirName, err := s.CreateWorkspaceFrom(domain.Workspace{ID: workspaceID, BranchName: branchName, Repos: repos})
+
+	return dirName, registered, err
+}
+
+// ExportRepoManifest renders a `repo` XML manifest pinning every workspace repo to its current HEAD.
+func (s *Service) ExportRepoManifest(workspaceID string) ([]byte, error) {
+	targetWorkspace, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	b := repomanifest.NewBuilder()
+
+	for _, repo := range targetWorkspace.Repos {
+		worktreePath := fmt.Sprintf("%s/%s/%s", s.config.WorkspacesRoot, dirName, repo.Name)
+
+		sha, err := s.gitEngine.RevParse(worktreePath, "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve HEAD of %s: %w", repo.Name, err)
+		}
+
+		upstream := ""
+		if repo.Ref == "" {
+			upstream = repoBranch(repo, targetWorkspace.BranchName)
+		}
+
+		b.AddProject(repo.Name, repo.URL, sha, upstream)
+	}
+
+	return b.Marshal()
+}
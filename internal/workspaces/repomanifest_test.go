@@ -0,0 +1,98 @@
+package workspaces
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+)
+
+func TestImportAndExportRepoManifest(t *testing.T) {
+	deps := newTestService(t)
+
+	source := filepath.Join(deps.projectsRoot, "src", "api")
+	createRepoWithCommit(t, source)
+	pinned := runGitOutput(t, source, "rev-parse", "HEAD")
+	runGit(t, source, "commit", "--allow-empty", "-m", "later")
+
+	registry, err := config.LoadRepoRegistry(filepath.Join(t.TempDir(), "repos.yaml"))
+	if err != nil {
+		t.Fatalf("LoadRepoRegistry failed: %v", err)
+	}
+
+	deps.svc.registry = registry
+
+	manifestFile := filepath.Join(t.TempDir(), "default.xml")
+	content := fmt.Sprintf(`<manifest>
+  <remote name="local" fetch="file://%s" />
+  <default remote="local" />
+  <project name="api" revision="%s" />
+</manifest>`, filepath.Dir(source), pinned)
+
+	if err := os.WriteFile(manifestFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	if _, registered, err := deps.svc.ImportRepoManifest(manifestFile, "", "PROJ-7", ""); err != nil || len(registered) != 1 {
+		t.Fatalf("ImportRepoManifest failed: %v (registered %v)", err, registered)
+	}
+
+	if entry, ok := registry.Resolve("api"); !ok || entry.URL != "file://"+source {
+		t.Fatalf("expected api registered, got %+v", entry)
+	}
+
+	worktree := filepath.Join(deps.workspacesRoot, "PROJ-7", "api")
+	if head := runGitOutput(t, worktree, "rev-parse", "HEAD"); head != pinned {
+		t.Fatalf("expected worktree at pinned revision %s, got %s", pinned, head)
+	}
+
+	out, err := deps.svc.ExportRepoManifest("PROJ-7")
+	if err != nil {
+		t.Fatalf("ExportRepoManifest failed: %v", err)
+	}
+
+	for _, want := range []string{`name="api"`, `revision="` + pinned + `"`, `upstream="PROJ-7"`, `fetch="file://` + filepath.Dir(source) + `"`} {
+		if !strings.Contains(string(out), want) {
+			t.Fatalf("exported manifest missing %s:\n%s", want, out)
+		}
+	}
+}
+
+func TestImportRepoManifestResolvesRelativeFetch(t *testing.T) {
+	deps := newTestService(t)
+
+	source := filepath.Join(deps.projectsRoot, "src", "api")
+	createRepoWithCommit(t, source)
+
+	// A `repo init` checkout: the manifest lives in a clone of the manifest repository.
+	manifestDir := t.TempDir()
+	runGit(t, manifestDir, "init")
+	runGit(t, manifestDir, "remote", "add", "origin", "file://"+filepath.Join(deps.projectsRoot, "src", "manifest"))
+
+	manifestFile := filepath.Join(manifestDir, "default.xml")
+	content := `<manifest>
+  <remote name="origin" fetch=".." />
+  <default remote="origin" revision="master" />
+  <project name="src/api" />
+</manifest>`
+
+	if err := os.WriteFile(manifestFile, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write manifest: %v", err)
+	}
+
+	if _, _, err := deps.svc.ImportRepoManifest(manifestFile, "", "PROJ-8", ""); err != nil {
+		t.Fatalf("ImportRepoManifest failed: %v", err)
+	}
+
+	ws, err := deps.svc.GetWorkspace("PROJ-8")
+	if err != nil {
+		t.Fatalf("GetWorkspace failed: %v", err)
+	}
+
+	if len(ws.Repos) != 1 || ws.Repos[0].URL != "file://"+source {
+		t.Fatalf("expected api resolved to file://%s, got %+v", source, ws.Repos)
+	}
+}
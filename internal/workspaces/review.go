@@ -0,0 +1,121 @@
+package workspaces
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+// ReviewRef selects the ref checked out for one repository of a review workspace.
+type ReviewRef struct {
+	Repo string
+	Ref  string
+}
+
+var pullShorthand = regexp.MustCompile(`^(?:pr|pull)/(\d+)$`)
+
+// ParseReviewRef parses a "repo=ref" argument.
+func ParseReviewRef(raw string) (ReviewRef, error) {
+	repo, ref, ok := strings.Cut(raw, "=")
+	repo, ref = strings.TrimSpace(repo), strings.TrimSpace(ref)
+
+	if !ok || repo == "" || ref == "" {
+		return ReviewRef{}, fmt.Errorf("invalid ref %q: expected REPO=REF", raw)
+	}
+
+	return ReviewRef{Repo: repo, Ref: ref}, nil
+}
+
+// normalizeReviewRef expands pr/N shorthand into the pull request head ref.
+func normalizeReviewRef(ref string) string {
+	if m := pullShorthand.FindStringSubmatch(ref); m != nil {
+		return fmt.Sprintf("refs/pull/%s/head", m[1])
+	}
+
+	return ref
+}
+
+// CreateReviewWorkspace creates an ephemeral workspace with detached, read-only checkouts of the
+// requested refs. The workspace expires after ttl, or the configured review_ttl when ttl is zero.
+func (s *Service) CreateReviewWorkspace(name string, refs []ReviewRef, ttl time.Duration) (string, error) {
+	if len(refs) == 0 {
+		return "", fmt.Errorf("at least one --ref is required")
+	}
+
+	names := make([]string, 0, len(refs))
+	for _, r := range refs {
+		names = append(names, r.Repo)
+	}
+
+	repos, err := s.ResolveRepos(name, names)
+	if err != nil {
+		return "", err
+	}
+
+	for i := range repos {
+		repos[i].Ref = normalizeReviewRef(refs[i].Ref)
+		repos[i].ReadOnly = true
+	}
+
+	if ttl <= 0 {
+		ttl = s.config.ReviewTTL
+	}
+
+	expiresAt := time.Now().UTC().Add(ttl)
+
+	return s.CreateWorkspaceFrom(domain.Workspace{
+		ID:        name,
+		Repos:     repos,
+		Ephemeral: true,
+		ExpiresAt: &expiresAt,
+	})
+}
+
+// PruneExpiredWorkspaces closes ephemeral workspaces whose TTL has elapsed and returns their IDs.
+// Workspaces with uncommitted changes or unpushed commits are kept unless force is set, and a
+// pre_close hook may veto closing one; both are logged and skipped.
+func (s *Service) PruneExpiredWorkspaces(now time.Time, force bool) ([]string, error) {
+	workspaces, err := s.wsEngine.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	var pruned []string
+
+	for dir, ws := range workspaces {
+		if !ws.IsExpired(now) {
+			continue
+		}
+
+		if !force {
+			if err := s.ensureWorkspaceClean(&ws, dir, "prune"); err != nil {
+				if s.logger != nil {
+					s.logger.Debug("Keeping expired workspace with local changes", "workspace", ws.ID, "error", err)
+				}
+
+				continue
+			}
+		}
+
+		// Cleanliness was checked above, so close without checking again.
+		if err := s.CloseWorkspace(ws.ID, true); err != nil {
+			if errors.Is(err, ErrHookVeto) {
+				if s.logger != nil {
+					s.logger.Info("Keeping expired workspace", "workspace", ws.ID, "error", err)
+				}
+
+				continue
+			}
+
+			return pruned, fmt.Errorf("failed to remove workspace %s: %w", ws.ID, err)
+		}
+
+		pruned = append(pruned, ws.ID)
+	}
+
+	return pruned, nil
+}
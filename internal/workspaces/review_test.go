@@ -0,0 +1,114 @@
+package workspaces
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+)
+
+func TestCreateReviewWorkspaceChecksOutPullRef(t *testing.T) {
+	deps := newTestService(t)
+
+	source := strings.TrimPrefix(newCanonicalRepos(t, deps, "api")[0].URL, "file://")
+
+	// Simulate a pull request head that is not on any branch.
+	runGit(t, source, "checkout", "-b", "contrib")
+	runGit(t, source, "commit", "--allow-empty", "-m", "pr change")
+	prHead := runGitOutput(t, source, "rev-parse", "HEAD")
+	runGit(t, source, "update-ref", "refs/pull/123/head", prHead)
+	runGit(t, source, "checkout", "-")
+	runGit(t, source, "branch", "-D", "contrib")
+
+	deps.svc.registry = &config.RepoRegistry{Repos: map[string]config.RegistryEntry{
+		"api": {Alias: "api", URL: source},
+	}}
+	deps.svc.config.ReviewTTL = time.Hour
+
+	ref, err := ParseReviewRef("api=pr/123")
+	if err != nil {
+		t.Fatalf("ParseReviewRef failed: %v", err)
+	}
+
+	if _, err := deps.svc.CreateReviewWorkspace("review-123", []ReviewRef{ref}, 0); err != nil {
+		t.Fatalf("CreateReviewWorkspace failed: %v", err)
+	}
+
+	worktree := filepath.Join(deps.workspacesRoot, "review-123", "api")
+	if got := runGitOutput(t, worktree, "rev-parse", "HEAD"); got != prHead {
+		t.Fatalf("expected HEAD %s, got %s", prHead, got)
+	}
+
+	ws, err := deps.svc.GetWorkspace("review-123")
+	if err != nil {
+		t.Fatalf("GetWorkspace failed: %v", err)
+	}
+
+	if !ws.Ephemeral || ws.ExpiresAt == nil || !ws.Repos[0].ReadOnly || ws.Repos[0].Ref != "refs/pull/123/head" {
+		t.Fatalf("unexpected review metadata: %+v", ws)
+	}
+
+	// Read-only repos are skipped, so pushing a detached review workspace succeeds without touching origin.
+	if _, err := deps.svc.PushWorkspace("review-123", PushOptions{}); err != nil {
+		t.Fatalf("PushWorkspace failed: %v", err)
+	}
+
+	pruned, err := deps.svc.PruneExpiredWorkspaces(time.Now(), false)
+	if err != nil || len(pruned) != 0 {
+		t.Fatalf("workspace should not be pruned before expiry: %v (%v)", pruned, err)
+	}
+
+	// Pruning closes the workspace like `workspace close`, running its hooks.
+	marker := filepath.Join(t.TempDir(), "closed")
+	deps.svc.config.Hooks = map[string][]config.HookConfig{
+		HookPostClose: {{Command: `echo "$CANOPY_WORKSPACE_ID" > ` + marker}},
+	}
+
+	pruned, err = deps.svc.PruneExpiredWorkspaces(ws.ExpiresAt.Add(time.Minute), false)
+	if err != nil || len(pruned) != 1 {
+		t.Fatalf("expected expired workspace to be pruned, got %v (%v)", pruned, err)
+	}
+
+	if data, err := os.ReadFile(marker); err != nil || string(data) != "review-123\n" {
+		t.Fatalf("expected post_close hook to run on prune, got %q (%v)", data, err)
+	}
+
+	if _, err := os.Stat(filepath.Join(deps.workspacesRoot, "review-123")); !os.IsNotExist(err) {
+		t.Fatalf("expected workspace directory to be removed, stat err: %v", err)
+	}
+}
+
+func TestParseReviewRef(t *testing.T) {
+	tests := []struct {
+		raw     string
+		want    ReviewRef
+		wantErr bool
+	}{
+		{raw: "backend=pr/123", want: ReviewRef{Repo: "backend", Ref: "pr/123"}},
+		{raw: "frontend = feature/x", want: ReviewRef{Repo: "frontend", Ref: "feature/x"}},
+		{raw: "backend", wantErr: true},
+		{raw: "=main", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseReviewRef(tt.raw)
+		if tt.wantErr {
+			if err == nil {
+				t.Fatalf("ParseReviewRef(%q) expected error", tt.raw)
+			}
+
+			continue
+		}
+
+		if err != nil || got != tt.want {
+			t.Fatalf("ParseReviewRef(%q) = %+v, %v; want %+v", tt.raw, got, err, tt.want)
+		}
+	}
+
+	if got := normalizeReviewRef("pr/42"); got != "refs/pull/42/head" {
+		t.Fatalf("normalizeReviewRef(pr/42) = %q", got)
+	}
+}
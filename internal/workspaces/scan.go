@@ -0,0 +1,154 @@
+package workspaces
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+	"github.com/alexisbeaulieu97/canopy/internal/secrets"
+)
+
+// ScanResult holds the suspected secrets in the outgoing commits of one repo.
+type ScanResult struct {
+	Repo     string
+	Range    string // commits scanned, e.g. origin/main..HEAD
+	Findings []secrets.Finding
+	Err      error
+}
+
+// ErrSecretsFound indicates outgoing commits contain suspected secrets.
+var ErrSecretsFound = errors.New("possible secrets found")
+
+// SecretsError reports the findings that blocked a push.
+type SecretsError struct {
+	Results []ScanResult
+}
+
+func (e *SecretsError) Error() string {
+	var b strings.Builder
+
+	b.WriteString(ErrSecretsFound.Error() + " in outgoing commits:")
+
+	for _, r := range e.Results {
+		for _, f := range r.Findings {
+			fmt.Fprintf(&b, "\n  %s: %s (commit %.7s)", r.Repo, f, f.Commit)
+		}
+	}
+
+	b.WriteString("\nremove them from the commits, list false positives in " + secrets.AllowlistFile + " or use --skip-scan")
+
+	return b.String()
+}
+
+func (e *SecretsError) Unwrap() error {
+	return ErrSecretsFound
+}
+
+// ScanOptions configures ScanWorkspace.
+type ScanOptions struct {
+	Selector RepoSelector
+	Remote   string // remote a push would go to; defaults to origin
+}
+
+// ScanWorkspace scans the commits each selected repo would push (those not yet on its
+// remote branch, or beyond its base when the branch was never pushed) for secrets. The
+// error wraps ErrSecretsFound when anything was found.
+func (s *Service) ScanWorkspace(workspaceID string, opts ScanOptions) ([]ScanResult, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	repos, err := s.selectRepos(ws, opts.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	remote := opts.Remote
+	if remote == "" {
+		remote = "origin"
+	}
+
+	rules, err := s.secretRules()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]ScanResult, 0, len(repos))
+
+	var found, failed []string
+
+	for _, repo := range repos {
+		path := filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)
+		result := s.scanRepo(path, repo, remote, s.gitEngine.HeadBranch(path), rules)
+
+		switch {
+		case result.Err != nil:
+			failed = append(failed, repo.Name)
+		case len(result.Findings) > 0:
+			found = append(found, repo.Name)
+		}
+
+		results = append(results, result)
+	}
+
+	if len(found) > 0 {
+		return results, fmt.Errorf("%w in %s", ErrSecretsFound, strings.Join(found, ", "))
+	}
+
+	if len(failed) > 0 {
+		return results, fmt.Errorf("failed to scan %s", strings.Join(failed, ", "))
+	}
+
+	return results, nil
+}
+
+// scanRepo scans the commits of a worktree that are not on remote/branch yet.
+func (s *Service) scanRepo(path string, repo domain.Repo, remote, branch string, rules []secrets.Rule) ScanResult {
+	base := s.repoBase(path, repo)
+	if branch != "HEAD" && branch != "" && s.gitEngine.HasRemoteBranch(path, remote, branch) {
+		base = remote + "/" + branch
+	}
+
+	result := ScanResult{Repo: repo.Name, Range: base + "..HEAD"}
+
+	allowlist, err := secrets.LoadAllowlist(filepath.Join(path, secrets.AllowlistFile))
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	added, err := s.gitEngine.AddedLines(path, result.Range)
+	if err != nil {
+		result.Err = err
+		return result
+	}
+
+	lines := make([]secrets.Line, len(added))
+	for i, l := range added {
+		lines[i] = secrets.Line{Commit: l.Commit, File: l.File, Number: l.Line, Text: l.Text}
+	}
+
+	result.Findings = secrets.NewScanner(rules, allowlist).Scan(lines)
+
+	return result
+}
+
+// secretRules compiles the secret rules from the config.
+func (s *Service) secretRules() ([]secrets.Rule, error) {
+	rules := make([]secrets.Rule, 0, len(s.config.Secrets.Rules))
+
+	for _, r := range s.config.Secrets.Rules {
+		re, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid secret rule %s: %w", r.Name, err)
+		}
+
+		rules = append(rules, secrets.Rule{Name: r.Name, Pattern: re})
+	}
+
+	return rules, nil
+}
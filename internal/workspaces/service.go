@@ -2,6 +2,8 @@
 package workspaces
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -9,12 +11,14 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"text/template"
 	"time"
 
 	"github.com/alexisbeaulieu97/canopy/internal/config"
 	"github.com/alexisbeaulieu97/canopy/internal/domain"
 	"github.com/alexisbeaulieu97/canopy/internal/gitx"
 	"github.com/alexisbeaulieu97/canopy/internal/logging"
+	"github.com/alexisbeaulieu97/canopy/internal/tracker"
 	"github.com/alexisbeaulieu97/canopy/internal/workspace"
 )
 
@@ -87,17 +91,75 @@ func (s *Service) ResolveRepos(workspaceID string, requestedRepos []string) ([]d
 
 // CreateWorkspace creates a new workspace directory and returns the directory name
 func (s *Service) CreateWorkspace(id, branchName string, repos []domain.Repo) (string, error) {
-	dirName := id
+	return s.CreateWorkspaceFrom(domain.Workspace{
+		ID:         id,
+		BranchName: branchName,
+		Repos:      repos,
+	})
+}
+
+// CreateWorkspaceFrom creates a workspace from the provided metadata (description, labels, repos)
+// and returns the directory name. Repo setup commands run once the worktrees exist; when
+// they fail the workspace is kept and a *SetupError is returned along with the directory.
+func (s *Service) CreateWorkspaceFrom(ws domain.Workspace) (string, error) {
+	return s.CreateWorkspaceWithOptions(ws, CreateOptions{})
+}
+
+// CreateOptions configures CreateWorkspaceWithOptions.
+type CreateOptions struct {
+	OverridePolicy bool // log branch policy violations instead of refusing to create
+}
+
+// CreateWorkspaceWithOptions is CreateWorkspaceFrom with explicit options.
+func (s *Service) CreateWorkspaceWithOptions(ws domain.Workspace, opts CreateOptions) (string, error) {
+	if ws.BranchName == "" {
+		ws.BranchName = ws.ID
+	}
+
+	if err := s.checkBranchPolicy(ws.ID, opts.OverridePolicy, workspaceBranches(ws)...); err != nil {
+		return "", err
+	}
+
+	dirName, err := s.createWorkspace(ws, HookPreCreate, HookPostCreate)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.runSetup(dirName, ws.ID, ws.Repos, false)
+
+	return dirName, err
+}
+
+// createWorkspace creates the workspace directory and worktrees, running the given lifecycle
+// hooks (an empty event runs none).
+func (s *Service) createWorkspace(ws domain.Workspace, preHook, postHook string) (string, error) {
+	dirName, err := s.workspaceDirName(ws)
+	if err != nil {
+		return "", err
+	}
 
 	// Default branch name is the workspace ID
-	if branchName == "" {
-		branchName = id
+	if ws.BranchName == "" {
+		ws.BranchName = ws.ID
 	}
 
-	if err := s.wsEngine.Create(dirName, id, branchName, repos); err != nil {
+	ws.Labels = normalizeLabels(ws.Labels)
+
+	if ws.Env, err = s.renderEnv(ws, dirName); err != nil {
 		return "", err
 	}
 
+	if err := s.runHooks(preHook, ws, dirName); err != nil {
+		return "", err
+	}
+
+	if err := s.wsEngine.CreateFrom(dirName, ws); err != nil {
+		return "", err
+	}
+
+	branchName := ws.BranchName
+	repos := ws.Repos
+
 	// Manual cleanup helper
 	cleanup := func() {
 		path := fmt.Sprintf("%s/%s", s.config.WorkspacesRoot, dirName)
@@ -106,24 +168,107 @@ func (s *Service) CreateWorkspace(id, branchName string, repos []domain.Repo) (s
 
 	// 3. Clone repositories (if any)
 	for _, repo := range repos {
-		// Ensure canonical exists
-		_, err := s.gitEngine.EnsureCanonical(repo.URL, repo.Name)
-		if err != nil {
-			cleanup()
-			return "", fmt.Errorf("failed to ensure canonical for %s: %w", repo.Name, err)
-		}
-
-		// Create worktree
-		worktreePath := fmt.Sprintf("%s/%s/%s", s.config.WorkspacesRoot, dirName, repo.Name)
-		if err := s.gitEngine.CreateWorktree(repo.Name, worktreePath, branchName); err != nil {
+		if err := s.addWorktree(dirName, branchName, repo); err != nil {
 			cleanup()
-			return "", fmt.Errorf("failed to create worktree for %s: %w", repo.Name, err)
+			return "", err
 		}
 	}
 
+	s.refreshWorkspaceFiles(dirName, ws)
+
+	_ = s.runHooks(postHook, ws, dirName)
+
 	return dirName, nil
 }
 
+// addWorktree ensures the canonical clone exists and creates the repo's worktree inside a workspace.
+func (s *Service) addWorktree(dirName, branchName string, repo domain.Repo) error {
+	if _, err := s.gitEngine.EnsureCanonical(repo.URL, repo.Name); err != nil {
+		return fmt.Errorf("failed to ensure canonical for %s: %w", repo.Name, err)
+	}
+
+	worktreePath := fmt.Sprintf("%s/%s/%s", s.config.WorkspacesRoot, dirName, repo.Name)
+
+	var err error
+
+	switch {
+	case repo.Ref != "":
+		err = s.gitEngine.CreateDetachedWorktree(repo.Name, worktreePath, repo.URL, repo.Ref)
+	case repo.Branch != "" || repo.BaseRef != "":
+		err = s.gitEngine.CreateWorktreeFrom(repo.Name, worktreePath, repoBranch(repo, branchName), repo.BaseRef)
+	default:
+		err = s.gitEngine.CreateWorktree(repo.Name, worktreePath, branchName)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to create worktree for %s: %w", repo.Name, err)
+	}
+
+	return nil
+}
+
+// removeWorktree deletes a repo's worktree directory from a workspace.
+func (s *Service) removeWorktree(dirName, repoName string) error {
+	worktreePath := fmt.Sprintf("%s/%s/%s", s.config.WorkspacesRoot, dirName, repoName)
+	if err := os.RemoveAll(worktreePath); err != nil {
+		return fmt.Errorf("failed to remove worktree %s: %w", worktreePath, err)
+	}
+
+	return nil
+}
+
+// repoBranch returns the branch a repo should be on: its own override or the workspace branch.
+func repoBranch(repo domain.Repo, workspaceBranch string) string {
+	if repo.Branch != "" {
+		return repo.Branch
+	}
+
+	return workspaceBranch
+}
+
+// FetchIssue looks up the tracker issue matching the workspace ID.
+// It returns nil without error when no tracker is configured for the ID.
+func (s *Service) FetchIssue(ctx context.Context, workspaceID string) (*domain.Issue, error) {
+	trackerCfg, ok := s.config.TrackerFor(workspaceID)
+	if !ok {
+		return nil, nil
+	}
+
+	client, err := tracker.New(trackerCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.FetchIssue(ctx, workspaceID)
+}
+
+// WorkspaceIDFromPath returns the ID of the workspace containing path.
+func (s *Service) WorkspaceIDFromPath(path string) (string, error) {
+	relPath, err := filepath.Rel(s.config.WorkspacesRoot, path)
+	if err != nil || relPath == "." || strings.HasPrefix(relPath, "..") {
+		return "", fmt.Errorf("not inside a workspace")
+	}
+
+	dirName := strings.Split(relPath, string(os.PathSeparator))[0]
+
+	ws, err := s.wsEngine.Load(dirName)
+	if err != nil {
+		return "", fmt.Errorf("unable to determine workspace from path: %w", err)
+	}
+
+	return ws.ID, nil
+}
+
+// GetWorkspace returns the metadata for an active workspace.
+func (s *Service) GetWorkspace(workspaceID string) (*domain.Workspace, error) {
+	ws, _, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return ws, nil
+}
+
 // WorkspacePath returns the absolute path for a workspace ID.
 func (s *Service) WorkspacePath(workspaceID string) (string, error) {
 	workspaces, err := s.wsEngine.List()
@@ -140,7 +285,8 @@ func (s *Service) WorkspacePath(workspaceID string) (string, error) {
 	return "", fmt.Errorf("workspace %s not found", workspaceID)
 }
 
-// AddRepoToWorkspace adds a repository to an existing workspace
+// AddRepoToWorkspace adds a repository to an existing workspace and runs its setup commands.
+// A *SetupError means the repo was added but its setup failed.
 func (s *Service) AddRepoToWorkspace(workspaceID, repoName string) error {
 	workspace, dirName, err := s.findWorkspace(workspaceID)
 	if err != nil {
@@ -163,21 +309,13 @@ func (s *Service) AddRepoToWorkspace(workspaceID, repoName string) error {
 	repo := repos[0]
 
 	// 4. Clone repo
-	// Ensure canonical exists
-	_, err = s.gitEngine.EnsureCanonical(repo.URL, repo.Name)
-	if err != nil {
-		return fmt.Errorf("failed to ensure canonical for %s: %w", repo.Name, err)
-	}
-
-	// Create worktree
 	branchName := workspace.BranchName
 	if branchName == "" {
 		return fmt.Errorf("workspace %s has no branch set in metadata", workspaceID)
 	}
 
-	worktreePath := fmt.Sprintf("%s/%s/%s", s.config.WorkspacesRoot, dirName, repo.Name)
-	if err := s.gitEngine.CreateWorktree(repo.Name, worktreePath, branchName); err != nil {
-		return fmt.Errorf("failed to create worktree for %s: %w", repo.Name, err)
+	if err := s.addWorktree(dirName, branchName, repo); err != nil {
+		return err
 	}
 
 	// 5. Update metadata
@@ -186,7 +324,11 @@ func (s *Service) AddRepoToWorkspace(workspaceID, repoName string) error {
 		return fmt.Errorf("failed to update workspace metadata: %w", err)
 	}
 
-	return nil
+	s.refreshWorkspaceFiles(dirName, *workspace)
+
+	_, err = s.runSetup(dirName, workspace.ID, []domain.Repo{repo}, false)
+
+	return err
 }
 
 // RemoveRepoFromWorkspace removes a repository from an existing workspace
@@ -211,9 +353,8 @@ func (s *Service) RemoveRepoFromWorkspace(workspaceID, repoName string) error {
 	}
 
 	// 3. Remove worktree directory
-	worktreePath := fmt.Sprintf("%s/%s/%s", s.config.WorkspacesRoot, dirName, repoName)
-	if err := os.RemoveAll(worktreePath); err != nil {
-		return fmt.Errorf("failed to remove worktree %s: %w", worktreePath, err)
+	if err := s.removeWorktree(dirName, repoName); err != nil {
+		return err
 	}
 
 	// 4. Update metadata
@@ -222,6 +363,8 @@ func (s *Service) RemoveRepoFromWorkspace(workspaceID, repoName string) error {
 		return fmt.Errorf("failed to update workspace metadata: %w", err)
 	}
 
+	s.refreshWorkspaceFiles(dirName, *workspace)
+
 	return nil
 }
 
@@ -238,8 +381,20 @@ func (s *Service) CloseWorkspace(workspaceID string, force bool) error {
 		}
 	}
 
+	if err := s.runHooks(HookPreClose, *targetWorkspace, dirName); err != nil {
+		return err
+	}
+
 	// 2. Delete workspace
-	return s.wsEngine.Delete(dirName)
+	s.killSession(targetWorkspace.ID)
+
+	if err := s.wsEngine.Delete(dirName); err != nil {
+		return err
+	}
+
+	_ = s.runHooks(HookPostClose, *targetWorkspace, dirName)
+
+	return nil
 }
 
 // ArchiveWorkspace moves workspace metadata to the archive store and removes the active worktree.
@@ -255,16 +410,24 @@ func (s *Service) ArchiveWorkspace(workspaceID string, force bool) (*workspace.A
 		}
 	}
 
+	if err := s.runHooks(HookPreArchive, *targetWorkspace, dirName); err != nil {
+		return nil, err
+	}
+
 	archived, err := s.wsEngine.Archive(dirName, *targetWorkspace, time.Now().UTC())
 	if err != nil {
 		return nil, err
 	}
 
+	s.killSession(targetWorkspace.ID)
+
 	if err := s.wsEngine.Delete(dirName); err != nil {
 		_ = s.wsEngine.DeleteArchive(archived.Path)
 		return nil, fmt.Errorf("failed to remove workspace directory: %w", err)
 	}
 
+	_ = s.runHooks(HookPostArchive, *targetWorkspace, dirName)
+
 	return archived, nil
 }
 
@@ -486,6 +649,11 @@ func (s *Service) SyncWorkspace(workspaceID string) error {
 
 	// 2. Iterate through repos and pull
 	for _, repo := range targetWorkspace.Repos {
+		// Detached review checkouts have no upstream branch to pull from.
+		if repo.Ref != "" {
+			continue
+		}
+
 		worktreePath := fmt.Sprintf("%s/%s/%s", s.config.WorkspacesRoot, dirName, repo.Name)
 		s.logger.Info("Syncing repo", "repo", repo.Name)
 		s.logger.Debug("Pulling changes", "path", worktreePath)
@@ -500,42 +668,29 @@ func (s *Service) SyncWorkspace(workspaceID string) error {
 	return nil
 }
 
-// PushWorkspace pushes all repos for a workspace.
-func (s *Service) PushWorkspace(workspaceID string) error {
+// SwitchBranch switches the branch for all repos in a workspace. Branch policy violations
+// are only logged when overridePolicy is set.
+func (s *Service) SwitchBranch(workspaceID, branchName string, create, overridePolicy bool) error {
 	targetWorkspace, dirName, err := s.findWorkspace(workspaceID)
 	if err != nil {
 		return err
 	}
 
-	for _, repo := range targetWorkspace.Repos {
-		worktreePath := fmt.Sprintf("%s/%s/%s", s.config.WorkspacesRoot, dirName, repo.Name)
-		branchName := targetWorkspace.BranchName
-
-		if branchName == "" {
-			if s.logger != nil {
-				s.logger.Debug("Branch missing in metadata, will let git infer", "workspace", workspaceID, "repo", repo.Name)
-			}
-		}
-
-		if err := s.gitEngine.Push(worktreePath, branchName); err != nil {
-			return fmt.Errorf("failed to push repo %s: %w", repo.Name, err)
-		}
+	if err := s.checkBranchPolicy(targetWorkspace.ID, overridePolicy, branchName); err != nil {
+		return err
 	}
 
-	return nil
-}
-
-// SwitchBranch switches the branch for all repos in a workspace
-func (s *Service) SwitchBranch(workspaceID, branchName string, create bool) error {
-	targetWorkspace, dirName, err := s.findWorkspace(workspaceID)
-	if err != nil {
+	targetEnv := "CANOPY_TARGET_BRANCH=" + branchName
+	if err := s.runHooks(HookPreSwitch, *targetWorkspace, dirName, targetEnv); err != nil {
 		return err
 	}
 
 	// 2. Iterate through repos and checkout
 	for _, repo := range targetWorkspace.Repos {
 		worktreePath := fmt.Sprintf("%s/%s/%s", s.config.WorkspacesRoot, dirName, repo.Name)
-		s.logger.Info("Switching branch", "repo", repo.Name, "branch", branchName)
+		if s.logger != nil {
+			s.logger.Info("Switching branch", "repo", repo.Name, "branch", branchName)
+		}
 
 		if err := s.gitEngine.Checkout(worktreePath, branchName, create); err != nil {
 			return fmt.Errorf("failed to checkout branch %s in repo %s: %w", branchName, repo.Name, err)
@@ -548,6 +703,8 @@ func (s *Service) SwitchBranch(workspaceID, branchName string, create bool) erro
 		return fmt.Errorf("failed to update workspace metadata: %w", err)
 	}
 
+	_ = s.runHooks(HookPostSwitch, *targetWorkspace, dirName, targetEnv)
+
 	return nil
 }
 
@@ -571,22 +728,137 @@ func (s *Service) RestoreWorkspace(workspaceID string, force bool) error {
 	ws := archive.Metadata
 	ws.ArchivedAt = nil
 
-	if _, err := s.CreateWorkspace(ws.ID, ws.BranchName, ws.Repos); err != nil {
+	dirName, err := s.createWorkspace(ws, HookPreRestore, "")
+	if err != nil {
 		return fmt.Errorf("failed to restore workspace %s: %w", workspaceID, err)
 	}
 
+	if err := s.wsEngine.RestoreNotes(archive, dirName); err != nil {
+		return fmt.Errorf("failed to restore notes for %s: %w", workspaceID, err)
+	}
+
 	if err := s.wsEngine.DeleteArchive(archive.Path); err != nil {
 		return fmt.Errorf("failed to remove archive entry: %w", err)
 	}
 
+	_ = s.runHooks(HookPostRestore, ws, dirName)
+
 	return nil
 }
 
+// DescribeWorkspace sets the description of a workspace. An empty description clears it.
+func (s *Service) DescribeWorkspace(workspaceID, description string) error {
+	targetWorkspace, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	targetWorkspace.Description = strings.TrimSpace(description)
+	if err := s.wsEngine.Save(dirName, *targetWorkspace); err != nil {
+		return fmt.Errorf("failed to update workspace metadata: %w", err)
+	}
+
+	return nil
+}
+
+// LabelWorkspace adds and removes labels on a workspace and returns the resulting label set.
+func (s *Service) LabelWorkspace(workspaceID string, add, remove []string) ([]string, error) {
+	targetWorkspace, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var labels []string
+
+	for _, l := range targetWorkspace.Labels {
+		if !containsFold(remove, l) {
+			labels = append(labels, l)
+		}
+	}
+
+	targetWorkspace.Labels = normalizeLabels(append(labels, add...))
+	if err := s.wsEngine.Save(dirName, *targetWorkspace); err != nil {
+		return nil, fmt.Errorf("failed to update workspace metadata: %w", err)
+	}
+
+	return targetWorkspace.Labels, nil
+}
+
+// AddWorkspaceNote appends a timestamped entry to the workspace notes file.
+func (s *Service) AddWorkspaceNote(workspaceID, note string) error {
+	_, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	note = strings.TrimSpace(note)
+	if note == "" {
+		return fmt.Errorf("note cannot be empty")
+	}
+
+	existing, err := s.wsEngine.ReadNotes(dirName)
+	if err != nil {
+		return err
+	}
+
+	if existing != "" && !strings.HasSuffix(existing, "\n") {
+		existing += "\n"
+	}
+
+	entry := fmt.Sprintf("- %s: %s\n", time.Now().Format("2006-01-02 15:04"), note)
+
+	return s.wsEngine.WriteNotes(dirName, existing+entry)
+}
+
+// WorkspaceNotes returns the notes recorded for a workspace.
+func (s *Service) WorkspaceNotes(workspaceID string) (string, error) {
+	_, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	return s.wsEngine.ReadNotes(dirName)
+}
+
+// WorkspaceNotesPath returns the notes file path for a workspace, whether or not it exists yet.
+func (s *Service) WorkspaceNotesPath(workspaceID string) (string, error) {
+	_, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return "", err
+	}
+
+	return s.wsEngine.NotesPath(dirName)
+}
+
 // StaleThresholdDays returns the configured stale threshold in days.
 func (s *Service) StaleThresholdDays() int {
 	return s.config.StaleThresholdDays
 }
 
+// workspaceDirName renders the configured workspace_naming template for a workspace.
+func (s *Service) workspaceDirName(ws domain.Workspace) (string, error) {
+	if strings.TrimSpace(s.config.WorkspaceNaming) == "" {
+		return ws.ID, nil
+	}
+
+	tmpl, err := template.New("workspace_naming").Parse(s.config.WorkspaceNaming)
+	if err != nil {
+		return "", fmt.Errorf("invalid workspace_naming template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, ws); err != nil {
+		return "", fmt.Errorf("failed to render workspace directory name: %w", err)
+	}
+
+	name := strings.Trim(strings.TrimSpace(buf.String()), "-_")
+	if name == "" {
+		return ws.ID, nil
+	}
+
+	return name, nil
+}
+
 func (s *Service) findWorkspace(workspaceID string) (*domain.Workspace, string, error) {
 	workspaces, err := s.wsEngine.List()
 	if err != nil {
@@ -623,6 +895,32 @@ func (s *Service) ensureWorkspaceClean(workspace *domain.Workspace, dirName, act
 	return nil
 }
 
+// normalizeLabels trims labels and removes empty and case-insensitive duplicates, keeping order.
+func normalizeLabels(labels []string) []string {
+	var out []string
+
+	for _, l := range labels {
+		l = strings.TrimSpace(l)
+		if l == "" || containsFold(out, l) {
+			continue
+		}
+
+		out = append(out, l)
+	}
+
+	return out
+}
+
+func containsFold(values []string, target string) bool {
+	for _, v := range values {
+		if strings.EqualFold(strings.TrimSpace(v), strings.TrimSpace(target)) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func isLikelyURL(val string) bool {
 	return strings.HasPrefix(val, "http://") ||
 		strings.HasPrefix(val, "https://") ||
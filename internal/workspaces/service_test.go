@@ -51,6 +51,38 @@ func newTestService(t *testing.T) testServiceDeps {
 	}
 }
 
+// newCanonicalRepos creates a source repo with one commit for each name, clones it bare into
+// the projects root and returns repos pointing at the sources.
+func newCanonicalRepos(t *testing.T, deps testServiceDeps, names ...string) []domain.Repo {
+	t.Helper()
+
+	sources := t.TempDir()
+	repos := make([]domain.Repo, 0, len(names))
+
+	for _, name := range names {
+		source := filepath.Join(sources, name)
+		createRepoWithCommit(t, source)
+		runGit(t, "", "clone", "--bare", source, filepath.Join(deps.projectsRoot, name))
+
+		repos = append(repos, domain.Repo{Name: name, URL: "file://" + source})
+	}
+
+	return repos
+}
+
+// newTestWorkspace creates ws with fresh canonical repos for names and returns those repos.
+func newTestWorkspace(t *testing.T, deps testServiceDeps, ws domain.Workspace, names ...string) []domain.Repo {
+	t.Helper()
+
+	ws.Repos = newCanonicalRepos(t, deps, names...)
+
+	if _, err := deps.svc.CreateWorkspaceFrom(ws); err != nil {
+		t.Fatalf("failed to create %s: %v", ws.ID, err)
+	}
+
+	return ws.Repos
+}
+
 func TestResolveRepos(t *testing.T) {
 	t.Parallel()
 
@@ -358,6 +390,85 @@ func TestRestoreWorkspaceForceDoesNotDeleteWithoutArchive(t *testing.T) {
 	}
 }
 
+func TestWorkspaceAnnotationsSurviveArchiveRestore(t *testing.T) {
+	deps := newTestService(t)
+
+	ws := domain.Workspace{ID: "PROJ-481", Description: "Login redirect", Labels: []string{"auth", " auth ", ""}}
+	if _, err := deps.svc.CreateWorkspaceFrom(ws); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	labels, err := deps.svc.LabelWorkspace("PROJ-481", []string{"urgent", "AUTH"}, nil)
+	if err != nil {
+		t.Fatalf("LabelWorkspace failed: %v", err)
+	}
+
+	if strings.Join(labels, ",") != "auth,urgent" {
+		t.Fatalf("unexpected labels: %v", labels)
+	}
+
+	if labels, err = deps.svc.LabelWorkspace("PROJ-481", nil, []string{"Urgent"}); err != nil || strings.Join(labels, ",") != "auth" {
+		t.Fatalf("expected label removal, got %v (%v)", labels, err)
+	}
+
+	if err := deps.svc.AddWorkspaceNote("PROJ-481", "waiting on design review"); err != nil {
+		t.Fatalf("AddWorkspaceNote failed: %v", err)
+	}
+
+	if _, err := deps.svc.ArchiveWorkspace("PROJ-481", true); err != nil {
+		t.Fatalf("ArchiveWorkspace failed: %v", err)
+	}
+
+	if err := deps.svc.RestoreWorkspace("PROJ-481", false); err != nil {
+		t.Fatalf("RestoreWorkspace failed: %v", err)
+	}
+
+	restored, err := deps.svc.GetWorkspace("PROJ-481")
+	if err != nil {
+		t.Fatalf("GetWorkspace failed: %v", err)
+	}
+
+	if restored.Description != "Login redirect" || !restored.HasLabel("auth") {
+		t.Fatalf("annotations not restored: %+v", restored)
+	}
+
+	notes, err := deps.svc.WorkspaceNotes("PROJ-481")
+	if err != nil {
+		t.Fatalf("WorkspaceNotes failed: %v", err)
+	}
+
+	if !strings.Contains(notes, "waiting on design review") {
+		t.Fatalf("notes not restored: %q", notes)
+	}
+}
+
+func TestCreateWorkspaceUsesNamingTemplate(t *testing.T) {
+	deps := newTestService(t)
+	deps.svc.config.WorkspaceNaming = "{{.ID}}{{if .Slug}}-{{.Slug}}{{end}}"
+
+	dirName, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-481", Slug: "fix-login"})
+	if err != nil {
+		t.Fatalf("CreateWorkspaceFrom failed: %v", err)
+	}
+
+	if dirName != "PROJ-481-fix-login" {
+		t.Fatalf("expected slugged directory, got %s", dirName)
+	}
+
+	id, err := deps.svc.WorkspaceIDFromPath(filepath.Join(deps.workspacesRoot, dirName, "backend"))
+	if err != nil {
+		t.Fatalf("WorkspaceIDFromPath failed: %v", err)
+	}
+
+	if id != "PROJ-481" {
+		t.Fatalf("expected PROJ-481, got %s", id)
+	}
+
+	if _, err := deps.svc.WorkspaceIDFromPath(deps.projectsRoot); err == nil {
+		t.Fatalf("expected error outside workspaces root")
+	}
+}
+
 func mustMkdir(t *testing.T, path string) {
 	t.Helper()
 
@@ -0,0 +1,70 @@
+package workspaces
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/alexisbeaulieu97/canopy/internal/session"
+)
+
+// OpenSession creates the multiplexer session for a workspace unless it is already running.
+// It returns the session name and whether it was created.
+func (s *Service) OpenSession(workspaceID string) (string, bool, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return "", false, err
+	}
+
+	mux, err := session.New(s.config.Session.Multiplexer)
+	if err != nil {
+		return "", false, err
+	}
+
+	name := session.Name(ws.ID)
+	if mux.Exists(name) {
+		return name, false, nil
+	}
+
+	root := filepath.Join(s.config.WorkspacesRoot, dirName)
+	windows := make([]session.Window, 0, len(ws.Repos)+1)
+
+	for _, repo := range ws.Repos {
+		windows = append(windows, session.Window{
+			Name:    repo.Name,
+			Dir:     filepath.Join(root, repo.Name),
+			Command: s.config.Session.Commands[repo.Name],
+		})
+	}
+
+	if len(windows) == 0 {
+		windows = append(windows, session.Window{Name: ws.ID, Dir: root})
+	}
+
+	if err := mux.Create(name, windows); err != nil {
+		return "", false, fmt.Errorf("failed to create session for %s: %w", ws.ID, err)
+	}
+
+	return name, true, nil
+}
+
+// AttachSession attaches the current terminal to a session opened by OpenSession.
+func (s *Service) AttachSession(name string) error {
+	mux, err := session.New(s.config.Session.Multiplexer)
+	if err != nil {
+		return err
+	}
+
+	return mux.Attach(name)
+}
+
+// killSession stops the workspace session, if any, when the workspace goes away.
+func (s *Service) killSession(workspaceID string) {
+	mux, err := session.New(s.config.Session.Multiplexer)
+	if err != nil {
+		return
+	}
+
+	if err := mux.Kill(session.Name(workspaceID)); err != nil && s.logger != nil {
+		s.logger.Warn("Failed to kill workspace session", "workspace", workspaceID, "error", err)
+	}
+}
@@ -0,0 +1,115 @@
+package workspaces
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+// installFakeTmux puts a tmux script on PATH that records its arguments and
+// tracks whether a session exists through a marker file.
+func installFakeTmux(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	logPath := filepath.Join(dir, "tmux.log")
+	script := `#!/bin/sh
+echo "$@" >> "` + logPath + `"
+case "$1" in
+  has-session) [ -f "` + dir + `/running" ] ;;
+  new-session) touch "` + dir + `/running" ;;
+  kill-session) rm -f "` + dir + `/running" ;;
+esac
+`
+
+	if err := os.WriteFile(filepath.Join(dir, "tmux"), []byte(script), 0o755); err != nil { //nolint:gosec // test helper needs an executable
+		t.Fatalf("failed to write fake tmux: %v", err)
+	}
+
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	return logPath
+}
+
+func TestWorkspaceSessionLifecycle(t *testing.T) {
+	logPath := installFakeTmux(t)
+
+	deps := newTestService(t)
+	deps.svc.config.Session.Commands = map[string]string{"web.v2": "npm run dev"}
+
+	repos := []domain.Repo{{Name: "api"}, {Name: "web.v2"}}
+	if err := deps.wsEngine.Create("PROJ-5", "PROJ-5", "PROJ-5", repos); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	name, created, err := deps.svc.OpenSession("PROJ-5")
+	if err != nil {
+		t.Fatalf("OpenSession failed: %v", err)
+	}
+
+	if name != "PROJ-5" || !created {
+		t.Fatalf("OpenSession() = %q, %v; want PROJ-5, true", name, created)
+	}
+
+	if _, created, err := deps.svc.OpenSession("PROJ-5"); err != nil || created {
+		t.Fatalf("second OpenSession() created=%v err=%v; want existing session", created, err)
+	}
+
+	if err := deps.svc.CloseWorkspace("PROJ-5", true); err != nil {
+		t.Fatalf("CloseWorkspace failed: %v", err)
+	}
+
+	data, err := os.ReadFile(logPath) //nolint:gosec // test reads its own log
+	if err != nil {
+		t.Fatalf("failed to read tmux log: %v", err)
+	}
+
+	log := string(data)
+	apiDir := filepath.Join(deps.workspacesRoot, "PROJ-5", "api")
+	webDir := filepath.Join(deps.workspacesRoot, "PROJ-5", "web.v2")
+
+	for _, want := range []string{
+		"new-session -d -s PROJ-5 -n api -c " + apiDir,
+		"new-window -t =PROJ-5: -n web_v2 -c " + webDir,
+		"send-keys -t =PROJ-5:web_v2 npm run dev Enter",
+		"kill-session -t =PROJ-5",
+	} {
+		if !strings.Contains(log, want) {
+			t.Fatalf("tmux log missing %q:\n%s", want, log)
+		}
+	}
+
+	if strings.Count(log, "new-session") != 1 {
+		t.Fatalf("expected a single new-session call:\n%s", log)
+	}
+}
+
+func TestArchiveWorkspaceKillsSession(t *testing.T) {
+	logPath := installFakeTmux(t)
+
+	deps := newTestService(t)
+
+	if err := deps.wsEngine.Create("PROJ-6", "PROJ-6", "PROJ-6", []domain.Repo{{Name: "api"}}); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	if _, _, err := deps.svc.OpenSession("PROJ-6"); err != nil {
+		t.Fatalf("OpenSession failed: %v", err)
+	}
+
+	if _, err := deps.svc.ArchiveWorkspace("PROJ-6", true); err != nil {
+		t.Fatalf("ArchiveWorkspace failed: %v", err)
+	}
+
+	data, err := os.ReadFile(logPath) //nolint:gosec // test reads its own log
+	if err != nil {
+		t.Fatalf("failed to read tmux log: %v", err)
+	}
+
+	if !strings.Contains(string(data), "kill-session -t =PROJ-6") {
+		t.Fatalf("archive did not kill the session:\n%s", data)
+	}
+}
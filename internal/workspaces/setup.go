@@ -0,0 +1,176 @@
+package workspaces
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+// setupLogDir holds setup logs and completion markers, relative to the workspace root.
+const setupLogDir = ".canopy/logs"
+
+// SetupResult reports the setup run of a single repo.
+type SetupResult struct {
+	Repo     string
+	Commands []string
+	LogPath  string
+	Skipped  bool // already set up, or nothing to run
+	Err      error
+}
+
+// SetupError lists the repos whose setup commands failed.
+type SetupError struct {
+	Failed []SetupResult
+}
+
+func (e *SetupError) Error() string {
+	names := make([]string, 0, len(e.Failed))
+	for _, r := range e.Failed {
+		names = append(names, r.Repo)
+	}
+
+	return fmt.Sprintf("setup failed for %s", strings.Join(names, ", "))
+}
+
+// SetupWorkspace runs setup commands for the given repos of a workspace, or all of them when
+// none are named. Repos whose setup already succeeded are skipped unless rerun is set.
+func (s *Service) SetupWorkspace(workspaceID string, repoNames []string, rerun bool) ([]SetupResult, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := ws.Repos
+
+	if len(repoNames) > 0 {
+		repos = nil
+
+		for _, name := range repoNames {
+			idx := slices.IndexFunc(ws.Repos, func(r domain.Repo) bool { return r.Name == name })
+			if idx < 0 {
+				return nil, fmt.Errorf("repository %s is not part of workspace %s", name, workspaceID)
+			}
+
+			repos = append(repos, ws.Repos[idx])
+		}
+	}
+
+	return s.runSetup(dirName, ws.ID, repos, rerun)
+}
+
+// runSetup runs each repo's setup commands inside its worktree, in parallel across repos.
+// Output is written to one log file per repo under the workspace's .canopy/logs directory.
+func (s *Service) runSetup(dirName, workspaceID string, repos []domain.Repo, rerun bool) ([]SetupResult, error) {
+	logDir := filepath.Join(s.config.WorkspacesRoot, dirName, setupLogDir)
+	results := make([]SetupResult, len(repos))
+
+	var wg sync.WaitGroup
+
+	for i, repo := range repos {
+		result := SetupResult{
+			Repo:     repo.Name,
+			Commands: s.setupCommands(workspaceID, repo),
+			LogPath:  filepath.Join(logDir, "setup-"+repo.Name+".log"),
+		}
+
+		donePath := filepath.Join(logDir, "setup-"+repo.Name+".done")
+
+		if len(result.Commands) == 0 || (!rerun && fileExists(donePath)) {
+			result.Skipped = true
+			results[i] = result
+
+			continue
+		}
+
+		wg.Add(1)
+
+		go func(i int, result SetupResult) {
+			defer wg.Done()
+
+			if s.logger != nil {
+				s.logger.Info("Running setup", "repo", result.Repo, "commands", len(result.Commands))
+			}
+
+			_ = os.Remove(donePath)
+
+			dir := filepath.Join(s.config.WorkspacesRoot, dirName, result.Repo)
+			result.Err = runSetupCommands(dir, result.LogPath, result.Commands)
+
+			if result.Err == nil {
+				_ = os.WriteFile(donePath, nil, 0o600)
+			}
+
+			results[i] = result
+		}(i, result)
+	}
+
+	wg.Wait()
+
+	var failed []SetupResult
+
+	for _, r := range results {
+		if r.Err != nil {
+			failed = append(failed, r)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, &SetupError{Failed: failed}
+	}
+
+	return results, nil
+}
+
+// setupCommands collects registry, workspace pattern and manifest setup commands for a repo.
+func (s *Service) setupCommands(workspaceID string, repo domain.Repo) []string {
+	var commands []string
+
+	if entry, ok := s.registryEntry(repo); ok {
+		commands = append(commands, entry.Setup...)
+	}
+
+	commands = append(commands, s.config.GetSetupForWorkspace(workspaceID)...)
+
+	return append(commands, repo.Setup...)
+}
+
+// runSetupCommands runs commands in order, stopping at the first failure.
+func runSetupCommands(dir, logPath string, commands []string) error {
+	if err := os.MkdirAll(filepath.Dir(logPath), 0o750); err != nil {
+		return fmt.Errorf("failed to create log directory: %w", err)
+	}
+
+	logFile, err := os.Create(logPath) //nolint:gosec // path is constructed internally
+	if err != nil {
+		return fmt.Errorf("failed to create setup log: %w", err)
+	}
+
+	defer func() { _ = logFile.Close() }()
+
+	for _, step := range commands {
+		_, _ = fmt.Fprintf(logFile, "$ %s\n", step)
+
+		cmd := exec.Command("sh", "-c", step) //nolint:gosec // setup commands come from the user's configuration
+		cmd.Dir = dir
+		cmd.Stdout = logFile
+		cmd.Stderr = logFile
+
+		if err := cmd.Run(); err != nil {
+			_, _ = fmt.Fprintf(logFile, "\n%s: %v\n", step, err)
+			return fmt.Errorf("%q failed: %w", step, err)
+		}
+	}
+
+	return nil
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
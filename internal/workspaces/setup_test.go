@@ -0,0 +1,99 @@
+package workspaces
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestSetupWorkspace(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	deps.svc.registry = &config.RepoRegistry{Repos: map[string]config.RegistryEntry{
+		"api": {Alias: "api", URL: "file:///api", Setup: []string{"echo api >> setup.txt"}},
+		"web": {Alias: "web", URL: "file:///web", Setup: []string{"echo broken; exit 3"}},
+	}}
+	deps.svc.config.Defaults.WorkspacePatterns = []config.WorkspacePattern{
+		{Pattern: "^PROJ-", Setup: []string{"echo pattern >> setup.txt"}},
+	}
+
+	repos := []domain.Repo{{Name: "api"}, {Name: "web"}, {Name: "docs"}}
+	if err := deps.wsEngine.Create("PROJ-6", "PROJ-6", "PROJ-6", repos); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	for _, r := range repos {
+		mustMkdir(t, filepath.Join(deps.workspacesRoot, "PROJ-6", r.Name))
+	}
+
+	results, err := deps.svc.SetupWorkspace("PROJ-6", nil, false)
+
+	var setupErr *SetupError
+	if !errors.As(err, &setupErr) || len(setupErr.Failed) != 1 || setupErr.Failed[0].Repo != "web" {
+		t.Fatalf("expected setup failure for web, got %v", err)
+	}
+
+	if len(results) != 3 || results[0].Err != nil || results[2].Err != nil {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+
+	apiOut := filepath.Join(deps.workspacesRoot, "PROJ-6", "api", "setup.txt")
+	if data, _ := os.ReadFile(apiOut); string(data) != "api\npattern\n" { //nolint:gosec // test reads its own output
+		t.Fatalf("api setup output = %q", data)
+	}
+
+	webLog, _ := os.ReadFile(results[1].LogPath)
+	if !strings.Contains(string(webLog), "broken") {
+		t.Fatalf("web log missing command output:\n%s", webLog)
+	}
+
+	// Succeeded repos are skipped on the next run unless rerun is set.
+	results, _ = deps.svc.SetupWorkspace("PROJ-6", []string{"api", "web"}, false)
+	if !results[0].Skipped || results[1].Skipped {
+		t.Fatalf("expected only api to be skipped: %+v", results)
+	}
+
+	if _, err := deps.svc.SetupWorkspace("PROJ-6", []string{"api"}, true); err != nil {
+		t.Fatalf("rerun failed: %v", err)
+	}
+
+	if data, _ := os.ReadFile(apiOut); strings.Count(string(data), "api") != 2 { //nolint:gosec // test reads its own output
+		t.Fatalf("expected api setup to rerun, got %q", data)
+	}
+}
+
+func TestWorkspaceCreationRunsSetup(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	repos := newCanonicalRepos(t, deps, "api", "web")
+	deps.svc.registry = &config.RepoRegistry{Repos: map[string]config.RegistryEntry{
+		"api": {Alias: "api", URL: repos[0].URL, Setup: []string{"echo api > setup.txt"}},
+		"web": {Alias: "web", URL: repos[1].URL, Setup: []string{"exit 3"}},
+	}}
+
+	dirName, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-31", Repos: repos[:1]})
+	if err != nil {
+		t.Fatalf("CreateWorkspaceFrom failed: %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(deps.workspacesRoot, dirName, "api", "setup.txt")); err != nil {
+		t.Fatalf("setup did not run on create: %v", err)
+	}
+
+	var setupErr *SetupError
+	if err := deps.svc.AddRepoToWorkspace("PROJ-31", "web"); !errors.As(err, &setupErr) || setupErr.Failed[0].Repo != "web" {
+		t.Fatalf("expected setup failure for the added repo, got %v", err)
+	}
+
+	ws, err := deps.svc.GetWorkspace("PROJ-31")
+	if err != nil || len(ws.Repos) != 2 {
+		t.Fatalf("the repo should stay in the workspace after a setup failure, got %+v (%v)", ws, err)
+	}
+}
@@ -0,0 +1,265 @@
+package workspaces
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+const (
+	// snapshotDir holds snapshot descriptions, relative to the workspace root.
+	snapshotDir = ".canopy/snapshots"
+	// snapshotRefPrefix is the ref namespace keeping snapshot commits alive in each repo.
+	snapshotRefPrefix = "refs/canopy/snapshots/"
+)
+
+var snapshotNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// RepoSnapshot is the saved state of one repo.
+type RepoSnapshot struct {
+	Repo   string `json:"repo"`
+	Branch string `json:"branch"` // "HEAD" when detached
+	Head   string `json:"head"`
+	Stash  string `json:"stash,omitempty"` // stash commit with uncommitted changes, if any
+}
+
+// Snapshot is a save point across all repos of a workspace.
+type Snapshot struct {
+	Name      string         `json:"name"`
+	CreatedAt time.Time      `json:"created_at"`
+	Repos     []RepoSnapshot `json:"repos"`
+}
+
+// Dirty reports whether any repo had uncommitted changes when the snapshot was taken.
+func (s Snapshot) Dirty() bool {
+	for _, r := range s.Repos {
+		if r.Stash != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SnapshotWorkspace records the HEAD, branch and uncommitted changes of every repo under
+// refs/canopy/snapshots/<name>/ without touching the worktrees. An empty name uses a timestamp.
+func (s *Service) SnapshotWorkspace(workspaceID, name string) (*Snapshot, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if name == "" {
+		name = now.Format("20060102-150405")
+	}
+
+	if !snapshotNamePattern.MatchString(name) || strings.Contains(name, "..") {
+		return nil, fmt.Errorf("invalid snapshot name %q: use letters, digits, '.', '_' and '-'", name)
+	}
+
+	if _, err := s.readSnapshot(dirName, name); err == nil {
+		return nil, fmt.Errorf("snapshot %s already exists in workspace %s", name, workspaceID)
+	}
+
+	return s.takeSnapshot(ws, dirName, name, now)
+}
+
+func (s *Service) takeSnapshot(ws *domain.Workspace, dirName, name string, now time.Time) (*Snapshot, error) {
+	snap := &Snapshot{Name: name, CreatedAt: now}
+
+	for _, repo := range ws.Repos {
+		path := filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)
+		refs := snapshotRefPrefix + name + "/"
+
+		head, err := s.gitEngine.RevParse(path, "HEAD")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read HEAD of %s: %w", repo.Name, err)
+		}
+
+		stash, err := s.gitEngine.SaveChanges(path, "canopy snapshot "+name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to save changes of %s: %w", repo.Name, err)
+		}
+
+		if err := s.gitEngine.UpdateRef(path, refs+"head", head); err != nil {
+			return nil, fmt.Errorf("failed to record snapshot in %s: %w", repo.Name, err)
+		}
+
+		if stash != "" {
+			if err := s.gitEngine.UpdateRef(path, refs+"stash", stash); err != nil {
+				return nil, fmt.Errorf("failed to record snapshot in %s: %w", repo.Name, err)
+			}
+		}
+
+		snap.Repos = append(snap.Repos, RepoSnapshot{
+			Repo:   repo.Name,
+			Branch: s.gitEngine.HeadBranch(path),
+			Head:   head,
+			Stash:  stash,
+		})
+	}
+
+	if err := s.writeSnapshot(dirName, snap); err != nil {
+		return nil, err
+	}
+
+	return snap, nil
+}
+
+// ListSnapshots returns the snapshots of a workspace, oldest first.
+func (s *Service) ListSnapshots(workspaceID string) ([]Snapshot, error) {
+	_, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := filepath.Glob(filepath.Join(s.config.WorkspacesRoot, dirName, snapshotDir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]Snapshot, 0, len(files))
+
+	for _, file := range files {
+		snap, err := s.readSnapshot(dirName, strings.TrimSuffix(filepath.Base(file), ".json"))
+		if err != nil {
+			return nil, err
+		}
+
+		snapshots = append(snapshots, *snap)
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].CreatedAt.Before(snapshots[j].CreatedAt) })
+
+	return snapshots, nil
+}
+
+// DropSnapshot deletes a snapshot and its refs.
+func (s *Service) DropSnapshot(workspaceID, name string) error {
+	_, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return err
+	}
+
+	snap, err := s.readSnapshot(dirName, name)
+	if err != nil {
+		return err
+	}
+
+	for _, r := range snap.Repos {
+		path := filepath.Join(s.config.WorkspacesRoot, dirName, r.Repo)
+		if err := s.gitEngine.DeleteRefs(path, snapshotRefPrefix+name+"/"); err != nil && s.logger != nil {
+			s.logger.Warn("Failed to delete snapshot refs", "repo", r.Repo, "snapshot", name, "error", err)
+		}
+	}
+
+	return os.Remove(s.snapshotPath(dirName, name))
+}
+
+// RollbackWorkspace restores every repo to a snapshot: branch, HEAD and uncommitted changes.
+// The current state is saved first as a "before-rollback-<time>" snapshot; if any repo fails
+// to restore, the repos already restored are put back to that state.
+func (s *Service) RollbackWorkspace(workspaceID, name string) (*Snapshot, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	snap, err := s.readSnapshot(dirName, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range snap.Repos {
+		if !containsRepo(ws.Repos, r.Repo) {
+			return nil, fmt.Errorf("snapshot %s includes %s, which is no longer part of workspace %s", name, r.Repo, workspaceID)
+		}
+	}
+
+	now := time.Now().UTC()
+
+	safety, err := s.takeSnapshot(ws, dirName, "before-rollback-"+now.Format("20060102-150405.000"), now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save the current state before rolling back: %w", err)
+	}
+
+	for i, r := range snap.Repos {
+		path := filepath.Join(s.config.WorkspacesRoot, dirName, r.Repo)
+		if err := s.gitEngine.RestoreState(path, r.Branch, r.Head, r.Stash); err != nil {
+			s.undoRollback(dirName, safety, snap.Repos[:i+1])
+			return safety, fmt.Errorf("failed to roll back %s, workspace left as it was: %w", r.Repo, err)
+		}
+	}
+
+	return safety, nil
+}
+
+// undoRollback restores the given repos to the safety snapshot taken before a rollback.
+func (s *Service) undoRollback(dirName string, safety *Snapshot, repos []RepoSnapshot) {
+	for _, r := range repos {
+		for _, saved := range safety.Repos {
+			if saved.Repo != r.Repo {
+				continue
+			}
+
+			path := filepath.Join(s.config.WorkspacesRoot, dirName, saved.Repo)
+			if err := s.gitEngine.RestoreState(path, saved.Branch, saved.Head, saved.Stash); err != nil && s.logger != nil {
+				s.logger.Warn("Failed to undo partial rollback", "repo", saved.Repo, "snapshot", safety.Name, "error", err)
+			}
+		}
+	}
+}
+
+func (s *Service) snapshotPath(dirName, name string) string {
+	return filepath.Join(s.config.WorkspacesRoot, dirName, snapshotDir, name+".json")
+}
+
+func (s *Service) readSnapshot(dirName, name string) (*Snapshot, error) {
+	data, err := os.ReadFile(s.snapshotPath(dirName, name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("snapshot %s not found", name)
+		}
+
+		return nil, err
+	}
+
+	var snap Snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return nil, fmt.Errorf("invalid snapshot %s: %w", name, err)
+	}
+
+	return &snap, nil
+}
+
+func (s *Service) writeSnapshot(dirName string, snap *Snapshot) error {
+	path := s.snapshotPath(dirName, snap.Name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(snap, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, data, 0o600)
+}
+
+func containsRepo(repos []domain.Repo, name string) bool {
+	for _, r := range repos {
+		if r.Name == name {
+			return true
+		}
+	}
+
+	return false
+}
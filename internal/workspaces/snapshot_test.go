@@ -0,0 +1,113 @@
+package workspaces
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestSnapshotAndRollback(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	newTestWorkspace(t, deps, domain.Workspace{ID: "PROJ-17"}, "api", "web")
+
+	worktree := func(name string) string { return filepath.Join(deps.workspacesRoot, "PROJ-17", name) }
+	write := func(path, content string) {
+		t.Helper()
+
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("failed to write %s: %v", path, err)
+		}
+	}
+
+	for _, name := range []string{"api", "web"} {
+		runGit(t, worktree(name), "config", "user.email", "test@example.com")
+		runGit(t, worktree(name), "config", "user.name", "Test User")
+	}
+
+	write(filepath.Join(worktree("api"), "README.md"), "work in progress")
+	write(filepath.Join(worktree("api"), "notes.txt"), "untracked")
+	runGit(t, worktree("api"), "add", "README.md")
+
+	status := runGitOutput(t, worktree("api"), "status", "--porcelain")
+
+	apiHead := runGitOutput(t, worktree("api"), "rev-parse", "HEAD")
+	webHead := runGitOutput(t, worktree("web"), "rev-parse", "HEAD")
+
+	snap, err := deps.svc.SnapshotWorkspace("PROJ-17", "before-refactor")
+	if err != nil {
+		t.Fatalf("SnapshotWorkspace failed: %v", err)
+	}
+
+	if len(snap.Repos) != 2 || snap.Repos[0].Stash == "" || snap.Repos[1].Stash != "" {
+		t.Fatalf("unexpected snapshot: %+v", snap)
+	}
+
+	if got := runGitOutput(t, worktree("api"), "status", "--porcelain"); got != status {
+		t.Fatalf("snapshot must leave the worktree and index untouched, status:\n%s\nwant:\n%s", got, status)
+	}
+
+	if stashes := runGitOutput(t, worktree("api"), "stash", "list"); stashes != "" {
+		t.Fatalf("snapshot must not use the stash list, got:\n%s", stashes)
+	}
+
+	if _, err := deps.svc.SnapshotWorkspace("PROJ-17", "before-refactor"); err == nil {
+		t.Fatalf("expected an error for a duplicate snapshot name")
+	}
+
+	// Move on: commit in one repo, change the other.
+	runGit(t, worktree("api"), "add", "-A")
+	runGit(t, worktree("api"), "commit", "-m", "refactor")
+	write(filepath.Join(worktree("web"), "README.md"), "scratch")
+
+	safety, err := deps.svc.RollbackWorkspace("PROJ-17", "before-refactor")
+	if err != nil {
+		t.Fatalf("RollbackWorkspace failed: %v", err)
+	}
+
+	if got := runGitOutput(t, worktree("api"), "rev-parse", "HEAD"); got != apiHead {
+		t.Fatalf("api HEAD = %s, want %s", got, apiHead)
+	}
+
+	if got := runGitOutput(t, worktree("web"), "rev-parse", "HEAD"); got != webHead {
+		t.Fatalf("web HEAD = %s, want %s", got, webHead)
+	}
+
+	for path, want := range map[string]string{
+		filepath.Join(worktree("api"), "README.md"): "work in progress",
+		filepath.Join(worktree("api"), "notes.txt"): "untracked",
+		filepath.Join(worktree("web"), "README.md"): "hello",
+	} {
+		if data, err := os.ReadFile(path); err != nil || string(data) != want {
+			t.Fatalf("%s = %q (%v), want %q", path, data, err, want)
+		}
+	}
+
+	if got := runGitOutput(t, worktree("api"), "status", "--porcelain"); got != status {
+		t.Fatalf("rollback must restore staged and untracked changes, status:\n%s\nwant:\n%s", got, status)
+	}
+
+	snapshots, err := deps.svc.ListSnapshots("PROJ-17")
+	if err != nil {
+		t.Fatalf("ListSnapshots failed: %v", err)
+	}
+
+	if len(snapshots) != 2 || snapshots[0].Name != "before-refactor" || snapshots[1].Name != safety.Name {
+		t.Fatalf("unexpected snapshots: %+v", snapshots)
+	}
+
+	if err := deps.svc.DropSnapshot("PROJ-17", "before-refactor"); err != nil {
+		t.Fatalf("DropSnapshot failed: %v", err)
+	}
+
+	if refs := runGitOutput(t, worktree("api"), "for-each-ref", snapshotRefPrefix+"before-refactor/"); refs != "" {
+		t.Fatalf("expected snapshot refs to be deleted, got %s", refs)
+	}
+
+	if _, err := deps.svc.RollbackWorkspace("PROJ-17", "before-refactor"); err == nil {
+		t.Fatalf("expected an error rolling back to a dropped snapshot")
+	}
+}
@@ -0,0 +1,303 @@
+package workspaces
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+// taskCacheDir holds cached task results, relative to the workspace root.
+const taskCacheDir = ".canopy/tasks"
+
+// TaskResult is the outcome of a task in one repo.
+type TaskResult struct {
+	Repo     string
+	Command  string
+	Cached   bool // skipped because it already passed for the same inputs
+	Skipped  bool // not run because a dependency failed
+	Duration time.Duration
+	Err      error
+}
+
+// ErrTaskFailed indicates a task failed or was skipped in at least one repo.
+var ErrTaskFailed = errors.New("task failed")
+
+// taskCacheEntry records a successful task run.
+type taskCacheEntry struct {
+	Command     string        `json:"command"`
+	Fingerprint string        `json:"fingerprint"`
+	Duration    time.Duration `json:"duration"`
+	RanAt       time.Time     `json:"ran_at"`
+}
+
+// RunTask runs a named task in every repo of a workspace that defines it, following the
+// depends_on order of the registry. A repo is skipped when one of its dependencies failed.
+// Successful runs are cached by the HEAD SHA and uncommitted changes of the repo and of the
+// workspace repos it transitively depends on, plus the workspace environment, so unchanged
+// repos are not run again unless noCache is set.
+func (s *Service) RunTask(ctx context.Context, workspaceID, task string, noCache bool, onLine func(repo, line string)) ([]TaskResult, error) {
+	ws, dirName, err := s.findWorkspace(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	commands := make(map[string]string)
+	deps := make(map[string][]string)
+
+	var repos []string
+
+	for _, repo := range ws.Repos {
+		entry, _ := s.registryEntry(repo)
+		deps[repo.Name] = entry.DependsOn
+
+		command := repo.Tasks[task]
+		if command == "" {
+			command = entry.Tasks[task]
+		}
+
+		if command == "" {
+			continue
+		}
+
+		repos = append(repos, repo.Name)
+		commands[repo.Name] = command
+	}
+
+	if len(repos) == 0 {
+		return nil, fmt.Errorf("no repository of workspace %s defines task %q", workspaceID, task)
+	}
+
+	order, err := taskOrder(repos, deps)
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := s.WorkspaceEnv(workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	wsEnv := make([]string, 0, len(env))
+	for _, k := range slices.Sorted(maps.Keys(env)) {
+		wsEnv = append(wsEnv, k+"="+env[k])
+	}
+
+	baseEnv := append(os.Environ(), wsEnv...)
+
+	emit := func(repo, line string) {
+		if onLine != nil {
+			onLine(repo, line)
+		}
+	}
+
+	root := filepath.Join(s.config.WorkspacesRoot, dirName)
+	results := make([]TaskResult, 0, len(order))
+	failed := make(map[string]bool)
+	trees := make(map[string]string)
+
+	treeOf := func(name string) (string, error) {
+		if fp, ok := trees[name]; ok {
+			return fp, nil
+		}
+
+		fp, err := s.gitEngine.TreeFingerprint(filepath.Join(root, name))
+		if err == nil {
+			trees[name] = fp
+		}
+
+		return fp, err
+	}
+
+	for _, name := range order {
+		result := TaskResult{Repo: name, Command: commands[name]}
+
+		if slices.ContainsFunc(deps[name], func(d string) bool { return failed[d] }) {
+			result.Skipped = true
+			failed[name] = true
+			results = append(results, result)
+
+			continue
+		}
+
+		path := filepath.Join(root, name)
+		cachePath := filepath.Join(root, taskCacheDir, name, task+".json")
+
+		fingerprint, fpErr := taskFingerprint(name, deps, wsEnv, treeOf)
+		if fpErr == nil && !noCache {
+			if cached, ok := readTaskCache(cachePath); ok && cached.Fingerprint == fingerprint && cached.Command == result.Command {
+				result.Cached = true
+				result.Duration = cached.Duration
+				results = append(results, result)
+
+				continue
+			}
+		}
+
+		run := runForeachCommand(ctx, name, path, result.Command, append(slices.Clone(baseEnv), "CANOPY_TASK="+task), emit)
+		result.Duration = run.Duration
+		result.Err = run.Err
+
+		if run.Err != nil {
+			failed[name] = true
+			_ = os.Remove(cachePath)
+		} else if fpErr == nil {
+			writeTaskCache(cachePath, taskCacheEntry{
+				Command:     result.Command,
+				Fingerprint: fingerprint,
+				Duration:    run.Duration,
+				RanAt:       time.Now().UTC(),
+			})
+		}
+
+		results = append(results, result)
+	}
+
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%w in %d of %d repos", ErrTaskFailed, len(failed), len(results))
+	}
+
+	return results, nil
+}
+
+// taskFingerprint hashes the inputs of a task run in a repo: its tree, the trees of the
+// workspace repos it transitively depends on and the workspace environment.
+func taskFingerprint(name string, deps map[string][]string, env []string, treeOf func(string) (string, error)) (string, error) {
+	h := sha256.New()
+
+	for _, repo := range append([]string{name}, transitiveDeps(name, deps)...) {
+		tree, err := treeOf(repo)
+		if err != nil {
+			return "", err
+		}
+
+		_, _ = fmt.Fprintf(h, "%s=%s\n", repo, tree)
+	}
+
+	for _, kv := range env {
+		_, _ = fmt.Fprintln(h, kv)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// transitiveDeps returns the sorted names of the repos name depends on, directly or through
+// other repos. Only repos present in deps, the workspace repos, are followed.
+func transitiveDeps(name string, deps map[string][]string) []string {
+	seen := map[string]bool{name: true}
+	pending := slices.Clone(deps[name])
+
+	for len(pending) > 0 {
+		d := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if _, ok := deps[d]; !ok || seen[d] {
+			continue
+		}
+
+		seen[d] = true
+		pending = append(pending, deps[d]...)
+	}
+
+	delete(seen, name)
+
+	return slices.Sorted(maps.Keys(seen))
+}
+
+// registryEntry looks up the registry entry of a workspace repo by alias or URL.
+func (s *Service) registryEntry(repo domain.Repo) (config.RegistryEntry, bool) {
+	if s.registry == nil {
+		return config.RegistryEntry{}, false
+	}
+
+	entry, ok := s.registry.Resolve(repo.Name)
+	if !ok && repo.URL != "" {
+		entry, ok = s.registry.ResolveByURL(repo.URL)
+	}
+
+	return entry, ok
+}
+
+// taskOrder sorts repos so dependencies come first, keeping the given order otherwise.
+// Dependencies on repos outside the list are ignored.
+func taskOrder(repos []string, deps map[string][]string) ([]string, error) {
+	order := make([]string, 0, len(repos))
+	done := make(map[string]bool, len(repos))
+
+	for len(order) < len(repos) {
+		progressed := false
+
+		for _, name := range repos {
+			if done[name] {
+				continue
+			}
+
+			ready := true
+
+			for _, d := range deps[name] {
+				if d != name && slices.Contains(repos, d) && !done[d] {
+					ready = false
+					break
+				}
+			}
+
+			if ready {
+				order = append(order, name)
+				done[name] = true
+				progressed = true
+			}
+		}
+
+		if !progressed {
+			var cycle []string
+
+			for _, name := range repos {
+				if !done[name] {
+					cycle = append(cycle, name)
+				}
+			}
+
+			return nil, fmt.Errorf("dependency cycle between %s", strings.Join(cycle, ", "))
+		}
+	}
+
+	return order, nil
+}
+
+func readTaskCache(path string) (taskCacheEntry, bool) {
+	data, err := os.ReadFile(path) //nolint:gosec // path is constructed internally
+	if err != nil {
+		return taskCacheEntry{}, false
+	}
+
+	var entry taskCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return taskCacheEntry{}, false
+	}
+
+	return entry, true
+}
+
+func writeTaskCache(path string, entry taskCacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+		return
+	}
+
+	_ = os.WriteFile(path, data, 0o600)
+}
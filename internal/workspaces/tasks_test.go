@@ -0,0 +1,123 @@
+package workspaces
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/config"
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestRunTask(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	deps.svc.registry = &config.RepoRegistry{Repos: map[string]config.RegistryEntry{
+		"api":  {Alias: "api", URL: "file:///api", Tasks: map[string]string{"test": `echo "$CANOPY_REPO" >> ../runs.txt`}},
+		"web":  {Alias: "web", URL: "file:///web", Tasks: map[string]string{"test": "exit 1"}, DependsOn: []string{"api"}},
+		"docs": {Alias: "docs", URL: "file:///docs"},
+	}}
+
+	// web comes first in the workspace but depends on api; its task is overridden per workspace.
+	repos := []domain.Repo{
+		{Name: "web", Tasks: map[string]string{"test": `echo "$CANOPY_REPO" >> ../runs.txt`}},
+		{Name: "api"},
+		{Name: "docs"},
+	}
+	if err := deps.wsEngine.Create("PROJ-9", "PROJ-9", "PROJ-9", repos); err != nil {
+		t.Fatalf("failed to create workspace: %v", err)
+	}
+
+	root := filepath.Join(deps.workspacesRoot, "PROJ-9")
+	for _, r := range repos {
+		createRepoWithCommit(t, filepath.Join(root, r.Name))
+	}
+
+	runs := func() string {
+		data, _ := os.ReadFile(filepath.Join(root, "runs.txt")) //nolint:gosec // test reads its own output
+		return string(data)
+	}
+
+	results, err := deps.svc.RunTask(context.Background(), "PROJ-9", "test", false, nil)
+	if err != nil {
+		t.Fatalf("RunTask failed: %v", err)
+	}
+
+	if len(results) != 2 || results[0].Repo != "api" || results[1].Repo != "web" {
+		t.Fatalf("expected api before web, got %+v", results)
+	}
+
+	if got := runs(); got != "api\nweb\n" {
+		t.Fatalf("unexpected runs %q", got)
+	}
+
+	// Unchanged trees are served from the cache; a dirty tree runs again.
+	if err := os.WriteFile(filepath.Join(root, "web", "new.txt"), []byte("x"), 0o600); err != nil {
+		t.Fatalf("failed to dirty web: %v", err)
+	}
+
+	results, err = deps.svc.RunTask(context.Background(), "PROJ-9", "test", false, nil)
+	if err != nil {
+		t.Fatalf("RunTask failed: %v", err)
+	}
+
+	if !results[0].Cached || results[1].Cached {
+		t.Fatalf("expected only api to be cached: %+v", results)
+	}
+
+	if got := runs(); got != "api\nweb\nweb\n" {
+		t.Fatalf("unexpected runs %q", got)
+	}
+
+	// Changing a dependency or the workspace environment runs its dependents again too.
+	if err := os.WriteFile(filepath.Join(root, "api", "new.txt"), []byte("x"), 0o600); err != nil {
+		t.Fatalf("failed to dirty api: %v", err)
+	}
+
+	if _, err := deps.svc.RunTask(context.Background(), "PROJ-9", "test", false, nil); err != nil {
+		t.Fatalf("RunTask failed: %v", err)
+	}
+
+	deps.svc.config.Env = []config.EnvVar{{Name: "MODE", Value: "ci"}}
+
+	if _, err := deps.svc.RunTask(context.Background(), "PROJ-9", "test", false, nil); err != nil {
+		t.Fatalf("RunTask failed: %v", err)
+	}
+
+	if got := runs(); got != "api\nweb\nweb\napi\nweb\napi\nweb\n" {
+		t.Fatalf("unexpected runs %q", got)
+	}
+
+	// A failing dependency skips its dependents.
+	ws, dirName, _ := deps.svc.findWorkspace("PROJ-9")
+	ws.Repos[1].Tasks = map[string]string{"test": "exit 2"}
+
+	if err := deps.wsEngine.Save(dirName, *ws); err != nil {
+		t.Fatalf("failed to save workspace: %v", err)
+	}
+
+	results, err = deps.svc.RunTask(context.Background(), "PROJ-9", "test", true, nil)
+	if !errors.Is(err, ErrTaskFailed) {
+		t.Fatalf("expected ErrTaskFailed, got %v", err)
+	}
+
+	if results[0].Err == nil || !results[1].Skipped {
+		t.Fatalf("expected api to fail and web to be skipped: %+v", results)
+	}
+
+	if _, err := deps.svc.RunTask(context.Background(), "PROJ-9", "deploy", false, nil); err == nil {
+		t.Fatalf("expected error for undefined task")
+	}
+}
+
+func TestTaskOrderCycle(t *testing.T) {
+	t.Parallel()
+
+	_, err := taskOrder([]string{"a", "b"}, map[string][]string{"a": {"b"}, "b": {"a"}})
+	if err == nil {
+		t.Fatalf("expected cycle error")
+	}
+}
@@ -0,0 +1,107 @@
+package workspaces
+
+import (
+	"fmt"
+	"maps"
+	"path/filepath"
+	"slices"
+)
+
+// Location kinds reported by Which.
+const (
+	LocationWorkspace = "workspace"
+	LocationArchived  = "archived"
+	LocationCanonical = "canonical"
+)
+
+// whichRemote is the remote of a worktree, the canonical repo, that workspace branches are pushed to.
+const whichRemote = "origin"
+
+// Location is a place where a commit or branch was found.
+type Location struct {
+	Kind        string // workspace | archived | canonical
+	WorkspaceID string // empty for canonical repos
+	Repo        string
+	Match       string // commit | branch
+	Ref         string // full SHA for commits, branch name for branches
+	Pushed      bool   // present in the canonical repo that workspaces push to
+	Path        string // worktree, or canonical repo; empty for archived workspaces
+}
+
+// Which finds the active workspaces, archived workspaces and canonical repos containing a
+// commit (full or abbreviated SHA) or a branch.
+func (s *Service) Which(query string) ([]Location, error) {
+	var locations []Location
+
+	active, err := s.wsEngine.List()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list workspaces: %w", err)
+	}
+
+	for _, dirName := range slices.Sorted(maps.Keys(active)) {
+		ws := active[dirName]
+
+		for _, repo := range ws.Repos {
+			path := filepath.Join(s.config.WorkspacesRoot, dirName, repo.Name)
+			branch := repoBranch(repo, ws.BranchName)
+			loc := Location{Kind: LocationWorkspace, WorkspaceID: ws.ID, Repo: repo.Name, Path: path}
+
+			if sha, ok := s.gitEngine.LookupCommit(path, query); ok {
+				loc.Match, loc.Ref, loc.Pushed = "commit", sha, s.gitEngine.IsPushed(path, sha, whichRemote, branch)
+				locations = append(locations, loc)
+			}
+
+			if s.gitEngine.HasLocalBranch(path, query) {
+				loc.Match, loc.Ref, loc.Pushed = "branch", query, s.gitEngine.IsPushed(path, "refs/heads/"+query, whichRemote, query)
+				locations = append(locations, loc)
+			}
+		}
+	}
+
+	archives, err := s.wsEngine.ListArchived()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list archived workspaces: %w", err)
+	}
+
+	// Archived workspaces have no worktrees, so they are matched in the canonical repo, which
+	// only holds what was pushed: by branch name from metadata, or by a commit on that branch.
+	for _, a := range archives {
+		for _, repo := range a.Metadata.Repos {
+			canonical := filepath.Join(s.config.ProjectsRoot, repo.Name)
+			branch := repoBranch(repo, a.Metadata.BranchName)
+			loc := Location{Kind: LocationArchived, WorkspaceID: a.Metadata.ID, Repo: repo.Name, Pushed: true}
+
+			if sha, ok := s.gitEngine.LookupCommit(canonical, query); ok && s.gitEngine.Contains(canonical, "refs/heads/"+branch, sha) {
+				loc.Match, loc.Ref = "commit", sha
+				locations = append(locations, loc)
+			}
+
+			if branch == query {
+				loc.Match, loc.Ref, loc.Pushed = "branch", query, s.gitEngine.HasLocalBranch(canonical, query)
+				locations = append(locations, loc)
+			}
+		}
+	}
+
+	names, err := s.gitEngine.List()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		path := filepath.Join(s.config.ProjectsRoot, name)
+		loc := Location{Kind: LocationCanonical, Repo: name, Path: path, Pushed: true}
+
+		if sha, ok := s.gitEngine.LookupCommit(path, query); ok {
+			loc.Match, loc.Ref = "commit", sha
+			locations = append(locations, loc)
+		}
+
+		if s.gitEngine.HasLocalBranch(path, query) {
+			loc.Match, loc.Ref = "branch", query
+			locations = append(locations, loc)
+		}
+	}
+
+	return locations, nil
+}
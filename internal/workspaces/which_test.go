@@ -0,0 +1,86 @@
+package workspaces
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/alexisbeaulieu97/canopy/internal/domain"
+)
+
+func TestWhich(t *testing.T) {
+	t.Parallel()
+
+	deps := newTestService(t)
+	repos := newCanonicalRepos(t, deps, "api")
+
+	for _, id := range []string{"PROJ-11", "PROJ-12"} {
+		if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: id, Repos: repos}); err != nil {
+			t.Fatalf("failed to create %s: %v", id, err)
+		}
+	}
+
+	if _, err := deps.svc.ArchiveWorkspace("PROJ-12", true); err != nil {
+		t.Fatalf("failed to archive PROJ-12: %v", err)
+	}
+
+	worktree := filepath.Join(deps.workspacesRoot, "PROJ-11", "api")
+	runGit(t, worktree, "-c", "user.email=test@example.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", "wip")
+	sha := runGitOutput(t, worktree, "rev-parse", "HEAD")
+
+	locations, err := deps.svc.Which(sha[:8])
+	if err != nil {
+		t.Fatalf("Which failed: %v", err)
+	}
+
+	if len(locations) != 1 {
+		t.Fatalf("expected the unpushed commit only in PROJ-11, got %+v", locations)
+	}
+
+	if loc := locations[0]; loc.Kind != LocationWorkspace || loc.WorkspaceID != "PROJ-11" || loc.Ref != sha || loc.Pushed || loc.Path != worktree {
+		t.Fatalf("unexpected location: %+v", loc)
+	}
+
+	runGit(t, worktree, "push", "origin", "PROJ-11")
+
+	locations, err = deps.svc.Which("PROJ-11")
+	if err != nil {
+		t.Fatalf("Which failed: %v", err)
+	}
+
+	if len(locations) != 2 || !locations[0].Pushed || locations[0].Match != "branch" || locations[1].Kind != LocationCanonical {
+		t.Fatalf("expected pushed workspace branch and canonical branch, got %+v", locations)
+	}
+
+	locations, err = deps.svc.Which("PROJ-12")
+	if err != nil {
+		t.Fatalf("Which failed: %v", err)
+	}
+
+	if len(locations) != 1 || locations[0].Kind != LocationArchived || locations[0].Pushed {
+		t.Fatalf("expected unpushed archived branch, got %+v", locations)
+	}
+
+	// A pushed commit is still found for an archived workspace through its canonical branch.
+	if _, err := deps.svc.CreateWorkspaceFrom(domain.Workspace{ID: "PROJ-13", Repos: repos}); err != nil {
+		t.Fatalf("failed to create PROJ-13: %v", err)
+	}
+
+	pushedTree := filepath.Join(deps.workspacesRoot, "PROJ-13", "api")
+	runGit(t, pushedTree, "-c", "user.email=test@example.com", "-c", "user.name=Test", "commit", "--allow-empty", "-m", "shipped")
+	runGit(t, pushedTree, "push", "origin", "PROJ-13")
+	pushedSHA := runGitOutput(t, pushedTree, "rev-parse", "HEAD")
+
+	if _, err := deps.svc.ArchiveWorkspace("PROJ-13", true); err != nil {
+		t.Fatalf("failed to archive PROJ-13: %v", err)
+	}
+
+	locations, err = deps.svc.Which(pushedSHA[:10])
+	if err != nil {
+		t.Fatalf("Which failed: %v", err)
+	}
+
+	if len(locations) != 2 || locations[0].Kind != LocationArchived || locations[0].WorkspaceID != "PROJ-13" ||
+		locations[0].Match != "commit" || !locations[0].Pushed || locations[1].Kind != LocationCanonical {
+		t.Fatalf("expected pushed commit in archived PROJ-13 and canonical repo, got %+v", locations)
+	}
+}